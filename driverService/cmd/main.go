@@ -0,0 +1,27 @@
+package main
+
+import (
+	"log"
+
+	"github.com/RipperAcskt/innotaxi-driver/internal/app"
+)
+
+// @title InnoTaxi Driver API
+// @version 1.0
+// @description API for taxi drivers
+// @termsOfService  http://swagger.io/terms/
+
+// @contact.name   API Support
+// @contact.email  ripper@gmail.com
+
+// @host      localhost:8081
+// @BasePath  /
+
+// @securityDefinitions.apikey Bearer
+// @in header
+// @name Authorization
+func main() {
+	if err := app.Run(); err != nil {
+		log.Fatalf("app run failed: %v", err)
+	}
+}
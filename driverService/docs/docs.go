@@ -0,0 +1,366 @@
+// Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "ripper@gmail.com"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/drivers/auth/sing-in": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "driver authentication",
+                "parameters": [
+                    {
+                        "description": "phone number and password",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.DriverSingIn"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "access_token: token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "error: err",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/drivers/auth/sing-up": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "registrate driver",
+                "parameters": [
+                    {
+                        "description": "account info",
+                        "name": "driver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.DriverSingUp"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "error: err, fields: errors of the fields",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "error: err",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/drivers/{id}/orders": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "driver"
+                ],
+                "summary": "get driver's trips",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "driver's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Order"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "403": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "error: err",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/drivers/{id}/rating": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "driver"
+                ],
+                "summary": "get driver's rating",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "driver's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "raiting: rating",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "401": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "403": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "error: err",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/drivers/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "driver"
+                ],
+                "summary": "change driver's status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "driver's id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "free or busy",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.DriverStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "401": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "403": {
+                        "description": "error: err",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "error: err",
+                        "schema": {}
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.Order": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "driver_id": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "taxi_type": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.DriverSingIn": {
+            "type": "object",
+            "required": [
+                "password",
+                "phone_number"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.DriverSingUp": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password",
+                "phone_number",
+                "taxi_type"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "taxi_type": {
+                    "type": "string",
+                    "enum": [
+                        "economy",
+                        "comfort",
+                        "business"
+                    ]
+                }
+            }
+        },
+        "service.DriverStatus": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "Bearer": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8081",
+	BasePath:         "/",
+	Schemes:          []string{},
+	Title:            "InnoTaxi Driver API",
+	Description:      "API for taxi drivers",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
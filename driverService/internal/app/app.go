@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/RipperAcskt/innotaxi-driver/internal/handler/grpc"
+	handler "github.com/RipperAcskt/innotaxi-driver/internal/handler/restapi"
+	"github.com/RipperAcskt/innotaxi-driver/internal/metrics"
+	"github.com/RipperAcskt/innotaxi-driver/internal/repo/postgres"
+	"github.com/RipperAcskt/innotaxi-driver/internal/server"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/RipperAcskt/innotaxi-driver/internal/tracing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+func Run() error {
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("config new failed: %w", err)
+	}
+
+	// Both gRPC servers and clients authenticate with the shared token, the
+	// services can't talk to each other without it.
+	if cfg.GRPC_TOKEN == "" {
+		return grpc.ErrTokenRequired
+	}
+
+	shutdownTracing, err := tracing.New(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("tracing new failed: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = shutdownTracing(ctx)
+	}()
+
+	postgres, err := postgres.New(cfg)
+	if err != nil {
+		return fmt.Errorf("postgres new failed: %w", err)
+	}
+	defer postgres.Close()
+
+	err = metrics.RegisterDB(postgres.DB, cfg.POSTGRES_DB_NAME)
+	if err != nil {
+		return fmt.Errorf("register db metrics failed: %w", err)
+	}
+
+	err = postgres.Migrate.Up()
+	if err != migrate.ErrNoChange && err != nil {
+		return fmt.Errorf("migrate up failed: %w", err)
+	}
+
+	client, err := grpc.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("grpc new client failed: %w", err)
+	}
+	defer client.Close()
+
+	config := zap.NewProductionEncoderConfig()
+	config.EncodeTime = zapcore.ISO8601TimeEncoder
+	consoleEncoder := zapcore.NewConsoleEncoder(config)
+	defaultLogLevel := zapcore.DebugLevel
+	core := zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), defaultLogLevel)
+	log := zap.New(core, zap.AddCaller())
+	defer func() {
+		err := log.Sync()
+		if err != nil {
+			log.Error("log sync failed: %w", zap.Error(err))
+		}
+	}()
+
+	service := service.New(postgres, client, service.NewJWKS(cfg), cfg)
+	handler := handler.New(service, cfg, log)
+	server := &server.Server{
+		Log: log,
+	}
+
+	go func() {
+		if err := server.Run(handler.InitRouters(), cfg); err != nil && err != http.ErrServerClosed {
+			log.Error(fmt.Sprintf("server run failed: %v", err))
+			return
+		}
+	}()
+
+	grpcServer := grpc.New(service, log, cfg)
+	go func() {
+		if err := grpcServer.Run(); err != nil {
+			log.Error(fmt.Sprintf("grpc server run failed: %v", err))
+			return
+		}
+	}()
+
+	if err := server.ShutDown(); err != nil {
+		return fmt.Errorf("server shut down failed: %w", err)
+	}
+	if err := grpcServer.Stop(); err != nil {
+		return fmt.Errorf("grpc server stop failed: %v", err)
+	}
+	return nil
+}
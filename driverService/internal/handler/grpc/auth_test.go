@@ -0,0 +1,49 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	handler "github.com/RipperAcskt/innotaxi-driver/internal/handler/grpc"
+)
+
+func TestAuth(t *testing.T) {
+	test := []struct {
+		name string
+		md   metadata.MD
+		code codes.Code
+	}{
+		{
+			name: "correct token",
+			md:   metadata.Pairs("authorization", "Bearer secret"),
+			code: codes.OK,
+		},
+		{
+			name: "wrong token",
+			md:   metadata.Pairs("authorization", "Bearer other"),
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "no token",
+			md:   metadata.MD{},
+			code: codes.Unauthenticated,
+		},
+	}
+
+	unary, _ := handler.Auth("secret")
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/proto.DriverMatching/RateOrder"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, status.Code(err), tt.code)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/RipperAcskt/innotaxi-driver/pkg/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	conn   *grpc.ClientConn
+	client proto.AuthServiceClient
+}
+
+func NewClient(cfg *config.Config) (*Client, error) {
+	conn, err := grpc.Dial(cfg.USER_GRPC_HOST,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(tokenCredentials(cfg.GRPC_TOKEN)),
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial failed: %w", err)
+	}
+
+	return &Client{conn, proto.NewAuthServiceClient(conn)}, nil
+}
+
+func (c *Client) GetJWT(ctx context.Context, id uint64) (*service.Token, error) {
+	params := &proto.Params{
+		DriverID: fmt.Sprint(id),
+		Type:     service.Driver,
+	}
+
+	res, err := c.client.GetJWT(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("get jwt failed: %w", err)
+	}
+
+	return &service.Token{
+		Access: res.AccessToken,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors returns the interceptor which passes the statuses of the calls and
+// logs every other error, sending it as Internal without its message.
+func Errors(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		res, err := handler(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		log.Error(info.FullMethod, zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+}
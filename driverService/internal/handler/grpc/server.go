@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/RipperAcskt/innotaxi-driver/internal/model"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/RipperAcskt/innotaxi-driver/pkg/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	listener   net.Listener
+	grpcServer *grpc.Server
+	s          *service.Service
+	log        *zap.Logger
+	cfg        *config.Config
+}
+
+func New(s *service.Service, log *zap.Logger, cfg *config.Config) *Server {
+	return &Server{nil, nil, s, log, cfg}
+}
+
+// Run serves DriverMatching to the callers which know GRPC_TOKEN.
+func (s *Server) Run() error {
+	listener, err := net.Listen("tcp", s.cfg.GRPC_HOST)
+
+	if err != nil {
+		return fmt.Errorf("listen failed: %w", err)
+	}
+
+	authUnary, authStream := Auth(s.cfg.GRPC_TOKEN)
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			otelgrpc.UnaryServerInterceptor(),
+			Metrics(),
+			Errors(s.log),
+			authUnary,
+		),
+		grpc.ChainStreamInterceptor(
+			otelgrpc.StreamServerInterceptor(),
+			authStream,
+		),
+	}
+	grpcServer := grpc.NewServer(opts...)
+
+	s.listener = listener
+	s.grpcServer = grpcServer
+
+	proto.RegisterDriverMatchingServer(grpcServer, s)
+	err = grpcServer.Serve(listener)
+	if err != nil {
+		return fmt.Errorf("serve failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) FindFreeDriver(c context.Context, req *proto.FindDriverRequest) (*proto.FindDriverResponse, error) {
+	order := &model.Order{
+		UserID:   req.UserID,
+		TaxiType: req.TaxiType,
+		From:     req.From,
+		To:       req.To,
+		Date:     time.Now().UTC(),
+		Status:   model.OrderStatusInProgress,
+	}
+
+	err := s.s.MatchDriver(c, order)
+	if err != nil {
+		if errors.Is(err, service.ErrFreeDriverNotFound) {
+			return nil, status.Error(codes.NotFound, service.ErrFreeDriverNotFound.Error())
+		}
+		return nil, fmt.Errorf("match driver failed: %w", err)
+	}
+
+	return &proto.FindDriverResponse{
+		DriverID: fmt.Sprint(order.DriverID),
+	}, nil
+}
+
+func (s *Server) RateOrder(c context.Context, req *proto.RateOrderRequest) (*proto.RateOrderResponse, error) {
+	err := s.s.RateOrder(c, req.DriverID, req.UserID, int(req.Rating))
+	if err != nil {
+		if errors.Is(err, service.ErrOrderNotFound) {
+			return nil, status.Error(codes.NotFound, service.ErrOrderNotFound.Error())
+		}
+		if errors.Is(err, service.ErrIncorrectRating) {
+			return nil, status.Error(codes.InvalidArgument, service.ErrIncorrectRating.Error())
+		}
+		return nil, fmt.Errorf("rate order failed: %w", err)
+	}
+
+	return &proto.RateOrderResponse{}, nil
+}
+
+func (s *Server) ReleaseDriver(c context.Context, req *proto.ReleaseDriverRequest) (*proto.ReleaseDriverResponse, error) {
+	err := s.s.ReleaseDriver(c, req.DriverID, req.UserID)
+	if err != nil {
+		if errors.Is(err, service.ErrOrderNotFound) {
+			return nil, status.Error(codes.NotFound, service.ErrOrderNotFound.Error())
+		}
+		return nil, fmt.Errorf("release driver failed: %w", err)
+	}
+
+	return &proto.ReleaseDriverResponse{}, nil
+}
+
+func (s *Server) WatchFreeDrivers(req *proto.WatchRequest, stream proto.DriverMatching_WatchFreeDriversServer) error {
+	drivers, cancel := s.s.SubscribeFreeDrivers()
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case driver := <-drivers:
+			err := stream.Send(&proto.FreeDriver{
+				DriverID: fmt.Sprint(driver.ID),
+				TaxiType: driver.TaxiType,
+			})
+			if err != nil {
+				return fmt.Errorf("send failed: %w", err)
+			}
+		}
+	}
+}
+
+func (s *Server) Stop() error {
+	s.log.Info("Shuttig down grpc...")
+
+	err := s.listener.Close()
+	if err != nil {
+		return fmt.Errorf("listener close failed: %w", err)
+	}
+
+	s.grpcServer.Stop()
+	s.log.Info("Grpc server exiting.")
+	return nil
+}
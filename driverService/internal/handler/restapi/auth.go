@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+)
+
+// @Summary registrate driver
+// @Tags auth
+// @Param driver body service.DriverSingUp true "account info"
+// @Accept json
+// @Success 201
+// @Failure 400 {object} error "error: err, fields: errors of the fields"
+// @Failure 500 {object} error "error: err"
+// @Router /drivers/auth/sing-up [POST]
+func (h *Handler) SingUp(c *gin.Context) {
+	var driver service.DriverSingUp
+
+	if !bind(c, &driver) {
+		return
+	}
+
+	err := h.s.SingUp(c.Request.Context(), driver)
+	if err != nil {
+		if e := publicError(err, service.ErrDriverAlreadyExists, service.ErrUnknownTaxiType); e != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": e.Error(),
+			})
+			return
+		}
+
+		abortInternal(c, fmt.Errorf("service sing up failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+// @Summary driver authentication
+// @Tags auth
+// @Param input body service.DriverSingIn true "phone number and password"
+// @Accept json
+// @Produce json
+// @Success 200 {object} string "access_token: token"
+// @Failure 403 {object} error "error: err"
+// @Failure 500 {object} error "error: err"
+// @Router /drivers/auth/sing-in [POST]
+func (h *Handler) SingIn(c *gin.Context) {
+	var driver service.DriverSingIn
+
+	if !bind(c, &driver) {
+		return
+	}
+	token, err := h.s.SingIn(c.Request.Context(), driver)
+	if err != nil {
+		if e := publicError(err, service.ErrIncorrectPassword); e != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": e.Error(),
+			})
+			return
+		}
+		abortInternal(c, fmt.Errorf("service sing in failed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": token.Access,
+	})
+}
+
+func (h *Handler) VerifyToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(token) < 2 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": fmt.Errorf("access token required").Error(),
+			})
+			return
+		}
+		accessToken := token[1]
+
+		id, err := h.s.Verify(accessToken)
+		if err != nil {
+			if e := publicError(err, service.ErrTokenExpired, service.ErrWrongTokenUse, service.ErrInvalidToken); e != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"error": e.Error(),
+				})
+				return
+			}
+			if e := publicError(err, service.ErrUnknownType); e != nil {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error": e.Error(),
+				})
+				return
+			}
+			if strings.Contains(err.Error(), jwt.ErrSignatureInvalid.Error()) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error": fmt.Errorf("wrong signature").Error(),
+				})
+				return
+			}
+
+			abortInternal(c, fmt.Errorf("service verify failed: %w", err))
+			return
+		}
+
+		c.Set("id", fmt.Sprint(id))
+		if c.Param("id") != "" && fmt.Sprint(id) != c.Param("id") {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary change driver's status
+// @Tags driver
+// @Param id path int true "driver's id"
+// @Param input body service.DriverStatus true "free or busy"
+// @Accept json
+// @Success 200
+// @Failure 400 {object} error "error: err"
+// @Failure 401 {object} error "error: err"
+// @Failure 403 {object} error "error: err"
+// @Failure 500 {object} error "error: err"
+// @Router /drivers/{id}/status [PUT]
+// @Security Bearer
+func (h *Handler) ChangeStatus(c *gin.Context) {
+	var status service.DriverStatus
+
+	if !bind(c, &status) {
+		return
+	}
+
+	err := h.s.ChangeStatus(c.Request.Context(), c.Param("id"), status)
+	if err != nil {
+		if e := publicError(err, service.ErrDriverDoesNotExists, service.ErrUnknownStatus); e != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": e.Error(),
+			})
+			return
+		}
+		abortInternal(c, fmt.Errorf("change status failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary get driver's trips
+// @Tags driver
+// @Param id path int true "driver's id"
+// @Produce json
+// @Success 200 {array} model.Order
+// @Failure 401 {object} error "error: err"
+// @Failure 403 {object} error "error: err"
+// @Failure 500 {object} error "error: err"
+// @Router /drivers/{id}/orders [GET]
+// @Security Bearer
+func (h *Handler) GetOrders(c *gin.Context) {
+	orders, err := h.s.GetOrders(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortInternal(c, fmt.Errorf("get orders failed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// @Summary get driver's rating
+// @Tags driver
+// @Param id path int true "driver's id"
+// @Produce json
+// @Success 200 {object} string "raiting: rating"
+// @Failure 400 {object} error "error: err"
+// @Failure 401 {object} error "error: err"
+// @Failure 403 {object} error "error: err"
+// @Failure 500 {object} error "error: err"
+// @Router /drivers/{id}/rating [GET]
+// @Security Bearer
+func (h *Handler) GetRating(c *gin.Context) {
+	rating, err := h.s.GetRating(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if e := publicError(err, service.ErrDriverDoesNotExists); e != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": e.Error(),
+			})
+			return
+		}
+		abortInternal(c, fmt.Errorf("get rating failed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"raiting": rating,
+	})
+}
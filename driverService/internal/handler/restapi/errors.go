@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+var (
+	errMalformedRequest = fmt.Errorf("malformed request")
+	errValidation       = fmt.Errorf("validation failed")
+	errInternal         = fmt.Errorf("internal error")
+)
+
+// publicError returns the first of targets in the chain of err, so the client
+// gets its message without the context err wraps it in. It returns nil if
+// err is none of them.
+func publicError(err error, targets ...error) error {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return target
+		}
+	}
+	return nil
+}
+
+// abortInternal logs err and answers the request with 500. The message of
+// err never reaches the client, it names queries and other services.
+func abortInternal(c *gin.Context, err error) {
+	getLogger(c).Error(c.FullPath(), zap.Error(err))
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+		"error": errInternal.Error(),
+	})
+}
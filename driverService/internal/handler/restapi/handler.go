@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.uber.org/zap"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	_ "github.com/RipperAcskt/innotaxi-driver/docs"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+)
+
+type Handler struct {
+	s   *service.Service
+	Cfg *config.Config
+	log *zap.Logger
+}
+
+func New(s *service.Service, cfg *config.Config, log *zap.Logger) *Handler {
+	registerValidations()
+	return &Handler{s, cfg, log}
+}
+
+func (h *Handler) InitRouters() *gin.Engine {
+	router := gin.New()
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	drivers := router.Group("/drivers")
+	drivers.Use(h.Log())
+
+	auth := drivers.Group("/auth")
+	auth.POST("sing-up", h.SingUp)
+	auth.POST("sing-in", h.SingIn)
+
+	drivers.PUT("/:id/status", h.VerifyToken(), h.ChangeStatus)
+	drivers.GET("/:id/orders", h.VerifyToken(), h.GetOrders)
+	drivers.GET("/:id/rating", h.VerifyToken(), h.GetRating)
+
+	return router
+}
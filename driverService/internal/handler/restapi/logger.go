@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (h *Handler) Log() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		logger := h.log.With(zap.String("url", c.Request.URL.Path), zap.String("method", c.Request.Method), zap.Any("uuid", uuid.New()), zap.String("request time", start.String()))
+		c.Set("logger", logger)
+		c.Next()
+
+		logger.Info("request", zap.String("time", time.Since(start).String()))
+	}
+}
+
+func getLogger(c *gin.Context) *zap.Logger {
+	tmp, _ := c.Get("logger")
+	return tmp.(*zap.Logger)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+)
+
+// validations are the tags of the sign up fields. The values are checked the
+// way the service normalizes them, so "+7 (900) 123-45-67" is a phone number.
+var validations = map[string]func(string) bool{
+	"phone": func(phone string) bool {
+		return service.ValidPhone(service.NormalizePhone(phone))
+	},
+	"mail": func(email string) bool {
+		return service.ValidEmail(service.NormalizeEmail(email))
+	},
+	"name": func(name string) bool {
+		return service.ValidName(service.NormalizeName(name))
+	},
+	"password": service.ValidPassword,
+}
+
+var messages = map[string]string{
+	"required": "is required",
+	"phone":    "must be a phone number in E.164 format",
+	"mail":     "must be an email address of up to 30 characters",
+	"name":     "must be up to 30 letters, spaces, hyphens and apostrophes",
+	"password": "must be 8 to 72 characters with a lowercase letter, an uppercase letter and a digit",
+	"oneof":    "must be one of %v",
+}
+
+// registerValidations adds the tags of the sign up fields to the validator of
+// gin and names the fields of the errors by their json keys.
+func registerValidations() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+		return field.Name
+	})
+
+	for tag, valid := range validations {
+		valid := valid
+		_ = v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return valid(fl.Field().String())
+		})
+	}
+}
+
+// bind binds the json body of the request. Malformed input and input which
+// breaks the rules of its fields are answered with 400, the latter with the
+// error of every field.
+func bind(c *gin.Context, obj interface{}) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": errMalformedRequest.Error(),
+		})
+		return false
+	}
+
+	fields := make(map[string]string, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		msg, ok := messages[fieldErr.Tag()]
+		if !ok {
+			msg = "is invalid"
+		}
+		if strings.Contains(msg, "%v") {
+			msg = fmt.Sprintf(msg, fieldErr.Param())
+		}
+		fields[fieldErr.Field()] = msg
+	}
+
+	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		"error":  errValidation.Error(),
+		"fields": fields,
+	})
+	return false
+}
@@ -0,0 +1,21 @@
+package model
+
+const (
+	StatusFree string = "free"
+	StatusBusy string = "busy"
+)
+
+const (
+	TaxiTypeEconomy  string = "economy"
+	TaxiTypeComfort  string = "comfort"
+	TaxiTypeBusiness string = "business"
+)
+
+type Driver struct {
+	ID          uint64 `json:"-"`
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phone_number"`
+	Email       string `json:"email"`
+	TaxiType    string `json:"taxi_type"`
+	Status      string `json:"status"`
+}
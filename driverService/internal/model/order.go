@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+const (
+	OrderStatusInProgress string = "in progress"
+	OrderStatusFinished   string = "finished"
+)
+
+type Order struct {
+	ID       uint64    `json:"id"`
+	DriverID uint64    `json:"driver_id"`
+	UserID   uint64    `json:"user_id"`
+	TaxiType string    `json:"taxi_type"`
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Date     time.Time `json:"date"`
+	Status   string    `json:"status"`
+	Rating   int       `json:"rating"`
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS drivers;
+
+DROP TYPE IF EXISTS driver_states;
+
+DROP TYPE IF EXISTS taxi_types;
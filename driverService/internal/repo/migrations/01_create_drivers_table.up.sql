@@ -0,0 +1,17 @@
+DROP TYPE IF EXISTS taxi_types;CREATE TYPE taxi_types as enum ('economy', 'comfort', 'business');
+
+DROP TYPE IF EXISTS driver_states;CREATE TYPE driver_states as enum ('free', 'busy');
+
+CREATE TABLE IF NOT EXISTS drivers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(30) NOT NULL,
+    phone_number VARCHAR(30) NOT NULL,
+    email VARCHAR(30) NOT NULL,
+    password BYTEA NOT NULL,
+    taxi_type taxi_types NOT NULL,
+    status driver_states NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS drivers_phone_number_idx ON drivers (phone_number);
+
+CREATE UNIQUE INDEX IF NOT EXISTS drivers_email_idx ON drivers (email);
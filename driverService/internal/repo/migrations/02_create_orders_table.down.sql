@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS orders;
+
+DROP TYPE IF EXISTS order_states;
@@ -0,0 +1,15 @@
+DROP TYPE IF EXISTS order_states;CREATE TYPE order_states as enum ('in progress', 'finished');
+
+CREATE TABLE IF NOT EXISTS orders (
+    id SERIAL PRIMARY KEY,
+    driver_id INTEGER NOT NULL REFERENCES drivers (id),
+    user_id INTEGER NOT NULL,
+    taxi_type taxi_types NOT NULL,
+    from_address VARCHAR(255) NOT NULL,
+    to_address VARCHAR(255) NOT NULL,
+    date TIMESTAMP NOT NULL DEFAULT NOW(),
+    status order_states NOT NULL,
+    rating INTEGER CHECK (rating BETWEEN 1 AND 5)
+);
+
+CREATE INDEX IF NOT EXISTS orders_driver_id_date_idx ON orders (driver_id, date DESC);
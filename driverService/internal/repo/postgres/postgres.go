@@ -0,0 +1,263 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/RipperAcskt/innotaxi-driver/internal/model"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const uniqueViolation = "23505"
+
+type Postgres struct {
+	DB      *sql.DB
+	Migrate *migrate.Migrate
+	cfg     *config.Config
+}
+
+func New(cfg *config.Config) (*Postgres, error) {
+	DB, err := sql.Open("pgx", cfg.GetPostgresUrl())
+	if err != nil {
+		return nil, fmt.Errorf("open failed: %w", err)
+	}
+
+	err = DB.Ping()
+	if err != nil {
+		return nil, fmt.Errorf("ping failed: %w", err)
+	}
+
+	driver, err := postgres.WithInstance(DB, &postgres.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("with instance failed: %w", err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(cfg.MIGRATE_PATH, "postgres", driver)
+	if err != nil {
+		return nil, fmt.Errorf("new with database instance failed: %w", err)
+	}
+
+	return &Postgres{
+		DB,
+		m,
+		cfg,
+	}, nil
+}
+
+func (p *Postgres) Close() error {
+	return p.DB.Close()
+}
+
+func (p *Postgres) CreateDriver(ctx context.Context, driver service.DriverSingUp) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := p.DB.ExecContext(queryCtx, "INSERT INTO drivers (name, phone_number, email, password, taxi_type, status) VALUES($1, $2, $3, $4, $5, $6)", driver.Name, driver.PhoneNumber, driver.Email, []byte(driver.Password), driver.TaxiType, model.StatusBusy)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("driver: %v: %w", driver.Name, service.ErrDriverAlreadyExists)
+		}
+		return fmt.Errorf("exec failed: %w", err)
+	}
+	return nil
+}
+
+func (p *Postgres) CheckDriverByPhoneNumber(ctx context.Context, phone_number string) (*service.DriverSingIn, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := p.DB.QueryRowContext(queryCtx, "SELECT id, phone_number, password FROM drivers WHERE phone_number = $1", phone_number)
+
+	var driver service.DriverSingIn
+
+	err := row.Scan(&driver.ID, &driver.PhoneNumber, &driver.Password)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, service.ErrDriverDoesNotExists
+		}
+
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+
+	return &driver, nil
+}
+
+// UpdateStatusById changes the driver's status and returns their taxi type. A
+// driver becoming free means the trip is over, so the driver's order in
+// progress is finished as well.
+func (p *Postgres) UpdateStatusById(ctx context.Context, id string, status string) (string, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := p.DB.BeginTx(queryCtx, nil)
+	if err != nil {
+		return "", fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	var taxiType string
+	err = tx.QueryRowContext(queryCtx, "UPDATE drivers SET status = $1 WHERE id = $2 RETURNING taxi_type", status, id).Scan(&taxiType)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", service.ErrDriverDoesNotExists
+		}
+		return "", fmt.Errorf("query row context failed: %w", err)
+	}
+
+	if status == model.StatusFree {
+		_, err = tx.ExecContext(queryCtx, "UPDATE orders SET status = $1 WHERE driver_id = $2 AND status = $3", model.OrderStatusFinished, id, model.OrderStatusInProgress)
+		if err != nil {
+			return "", fmt.Errorf("exec context failed: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", fmt.Errorf("commit failed: %w", err)
+	}
+	return taxiType, nil
+}
+
+// TakeFreeDriver marks a free driver of the order's taxi type as busy and
+// creates the order for them in one transaction. Rows locked by concurrent
+// calls are skipped, so a driver is never given to two users.
+func (p *Postgres) TakeFreeDriver(ctx context.Context, order *model.Order) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := p.DB.BeginTx(queryCtx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(queryCtx, "UPDATE drivers SET status = $1 WHERE id = (SELECT id FROM drivers WHERE status = $2 AND taxi_type = $3 ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED) RETURNING id", model.StatusBusy, model.StatusFree, order.TaxiType).Scan(&order.DriverID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return service.ErrFreeDriverNotFound
+		}
+		return fmt.Errorf("query row context failed: %w", err)
+	}
+
+	err = tx.QueryRowContext(queryCtx, "INSERT INTO orders (driver_id, user_id, taxi_type, from_address, to_address, date, status) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id", order.DriverID, order.UserID, order.TaxiType, order.From, order.To, order.Date, order.Status).Scan(&order.ID)
+	if err != nil {
+		return fmt.Errorf("query row context failed: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit failed: %w", err)
+	}
+	return nil
+}
+
+func (p *Postgres) GetOrdersByDriverId(ctx context.Context, id string) ([]*model.Order, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := p.DB.QueryContext(queryCtx, "SELECT id, driver_id, user_id, taxi_type, from_address, to_address, date, status, COALESCE(rating, 0) FROM orders WHERE driver_id = $1 ORDER BY date DESC", id)
+	if err != nil {
+		return nil, fmt.Errorf("query context failed: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]*model.Order, 0)
+	for rows.Next() {
+		order := &model.Order{}
+		err := rows.Scan(&order.ID, &order.DriverID, &order.UserID, &order.TaxiType, &order.From, &order.To, &order.Date, &order.Status, &order.Rating)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows failed: %w", err)
+	}
+
+	return orders, nil
+}
+
+func (p *Postgres) GetRatingById(ctx context.Context, id string) (float64, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var rating float64
+	err := p.DB.QueryRowContext(queryCtx, "SELECT COALESCE(AVG(orders.rating), 0) FROM drivers LEFT JOIN orders ON orders.driver_id = drivers.id WHERE drivers.id = $1 GROUP BY drivers.id", id).Scan(&rating)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, service.ErrDriverDoesNotExists
+		}
+		return 0, fmt.Errorf("query row context failed: %w", err)
+	}
+
+	return rating, nil
+}
+
+func (p *Postgres) RateLastOrder(ctx context.Context, driverID string, userID uint64, rating int) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := p.DB.ExecContext(queryCtx, "UPDATE orders SET rating = $1 WHERE id = (SELECT id FROM orders WHERE driver_id = $2 AND user_id = $3 AND status = $4 ORDER BY date DESC LIMIT 1)", rating, driverID, userID, model.OrderStatusFinished)
+	if err != nil {
+		return fmt.Errorf("exec context failed: %w", err)
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+	if num == 0 {
+		return service.ErrOrderNotFound
+	}
+	return nil
+}
+
+// ReleaseDriver drops the driver's order in progress with the user and makes
+// the driver free again. It returns the driver's taxi type, or
+// ErrOrderNotFound if they have no such order.
+func (p *Postgres) ReleaseDriver(ctx context.Context, driverID string, userID uint64) (string, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := p.DB.BeginTx(queryCtx, nil)
+	if err != nil {
+		return "", fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(queryCtx, "DELETE FROM orders WHERE driver_id = $1 AND user_id = $2 AND status = $3", driverID, userID, model.OrderStatusInProgress)
+	if err != nil {
+		return "", fmt.Errorf("exec context failed: %w", err)
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("rows affected failed: %w", err)
+	}
+	if num == 0 {
+		return "", service.ErrOrderNotFound
+	}
+
+	var taxiType string
+	err = tx.QueryRowContext(queryCtx, "UPDATE drivers SET status = $1 WHERE id = $2 RETURNING taxi_type", model.StatusFree, driverID).Scan(&taxiType)
+	if err != nil {
+		return "", fmt.Errorf("query row context failed: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", fmt.Errorf("commit failed: %w", err)
+	}
+	return taxiType, nil
+}
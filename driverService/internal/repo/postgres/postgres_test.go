@@ -0,0 +1,304 @@
+package postgres_test
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/RipperAcskt/innotaxi-driver/internal/model"
+	"github.com/RipperAcskt/innotaxi-driver/internal/repo/postgres"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestCreateDriver(t *testing.T) {
+	test := []struct {
+		name   string
+		driver service.DriverSingUp
+		err    error
+	}{
+		{
+			name: "add driver",
+			driver: service.DriverSingUp{
+				Name:        "Ivan",
+				PhoneNumber: "+7455456",
+				Email:       "ripper@algsdh",
+				Password:    "12345",
+				TaxiType:    model.TaxiTypeEconomy,
+			},
+			err: nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectExec("INSERT INTO drivers").WithArgs(tt.driver.Name, tt.driver.PhoneNumber, tt.driver.Email, []byte(tt.driver.Password), tt.driver.TaxiType, model.StatusBusy).WillReturnResult(sqlmock.NewResult(1, 1))
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.CreateDriver(context.Background(), tt.driver)
+			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestUpdateStatusById(t *testing.T) {
+	test := []struct {
+		name   string
+		status string
+		rows   int64
+		err    error
+	}{
+		{
+			name:   "driver becomes free",
+			status: model.StatusFree,
+			rows:   1,
+			err:    nil,
+		},
+		{
+			name:   "driver becomes busy",
+			status: model.StatusBusy,
+			rows:   1,
+			err:    nil,
+		},
+		{
+			name:   "driver does not exist",
+			status: model.StatusFree,
+			rows:   0,
+			err:    service.ErrDriverDoesNotExists,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			rows := sqlmock.NewRows([]string{"taxi_type"})
+			if tt.rows != 0 {
+				rows.AddRow(model.TaxiTypeEconomy)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("UPDATE drivers SET status").WithArgs(tt.status, "1").WillReturnRows(rows)
+			if tt.rows == 0 {
+				mock.ExpectRollback()
+			} else {
+				if tt.status == model.StatusFree {
+					mock.ExpectExec("UPDATE orders SET status").WithArgs(model.OrderStatusFinished, "1", model.OrderStatusInProgress).WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				mock.ExpectCommit()
+			}
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			_, err = postgres.UpdateStatusById(context.Background(), "1", tt.status)
+			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestGetRatingById(t *testing.T) {
+	test := []struct {
+		name   string
+		rating float64
+		err    error
+	}{
+		{
+			name:   "get rating",
+			rating: 4.5,
+			err:    nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			rows := sqlmock.NewRows([]string{"avg"}).AddRow(tt.rating)
+			mock.ExpectQuery("SELECT COALESCE").WithArgs("1").WillReturnRows(rows)
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			rating, err := postgres.GetRatingById(context.Background(), "1")
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, rating, tt.rating)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestTakeFreeDriver(t *testing.T) {
+	test := []struct {
+		name  string
+		found bool
+		err   error
+	}{
+		{
+			name:  "driver found",
+			found: true,
+			err:   nil,
+		},
+		{
+			name:  "driver not found",
+			found: false,
+			err:   service.ErrFreeDriverNotFound,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			order := &model.Order{
+				UserID:   1,
+				TaxiType: model.TaxiTypeComfort,
+				From:     "a",
+				To:       "b",
+				Date:     time.Now(),
+				Status:   model.OrderStatusInProgress,
+			}
+
+			drivers := sqlmock.NewRows([]string{"id"})
+			if tt.found {
+				drivers.AddRow(3)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("UPDATE drivers SET status").WithArgs(model.StatusBusy, model.StatusFree, order.TaxiType).WillReturnRows(drivers)
+			if tt.found {
+				mock.ExpectQuery("INSERT INTO orders").WithArgs(uint64(3), order.UserID, order.TaxiType, order.From, order.To, order.Date, order.Status).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.TakeFreeDriver(context.Background(), order)
+			assert.Equal(t, err, tt.err)
+			if tt.found {
+				assert.Equal(t, order.DriverID, uint64(3))
+			}
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestRateLastOrder(t *testing.T) {
+	test := []struct {
+		name string
+		rows int64
+		err  error
+	}{
+		{
+			name: "rate order",
+			rows: 1,
+			err:  nil,
+		},
+		{
+			name: "no finished order",
+			rows: 0,
+			err:  service.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectExec("UPDATE orders SET rating").WithArgs(5, "1", uint64(2), model.OrderStatusFinished).WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.RateLastOrder(context.Background(), "1", 2, 5)
+			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestReleaseDriver(t *testing.T) {
+	test := []struct {
+		name     string
+		rows     int64
+		taxiType string
+		err      error
+	}{
+		{
+			name:     "release driver",
+			rows:     1,
+			taxiType: model.TaxiTypeEconomy,
+			err:      nil,
+		},
+		{
+			name:     "no order in progress",
+			rows:     0,
+			taxiType: "",
+			err:      service.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM orders").WithArgs("1", uint64(2), model.OrderStatusInProgress).WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			if tt.rows != 0 {
+				mock.ExpectQuery("UPDATE drivers SET status").WithArgs(model.StatusFree, "1").WillReturnRows(sqlmock.NewRows([]string{"taxi_type"}).AddRow(tt.taxiType))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			taxiType, err := postgres.ReleaseDriver(context.Background(), "1", 2)
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, taxiType, tt.taxiType)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
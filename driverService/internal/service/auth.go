@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/RipperAcskt/innotaxi-driver/internal/model"
+)
+
+var (
+	ErrDriverAlreadyExists = fmt.Errorf("driver already exists")
+	ErrDriverDoesNotExists = fmt.Errorf("driver does not exists")
+	ErrIncorrectPassword   = fmt.Errorf("incorrect password")
+	ErrUnknownTaxiType     = fmt.Errorf("unknown taxi type")
+)
+
+type DriverSingUp struct {
+	Name        string `json:"name" binding:"required,name"`
+	PhoneNumber string `json:"phone_number" binding:"required,phone"`
+	Email       string `json:"email" binding:"required,mail"`
+	Password    string `json:"password" binding:"required,password"`
+	TaxiType    string `json:"taxi_type" binding:"required,oneof=economy comfort business"`
+}
+
+type DriverSingIn struct {
+	ID          uint64 `json:"-"`
+	PhoneNumber string `json:"phone_number" binding:"required"`
+	Password    string `json:"password" binding:"required"`
+}
+
+type AuthRepo interface {
+	CreateDriver(ctx context.Context, driver DriverSingUp) error
+	CheckDriverByPhoneNumber(ctx context.Context, phone string) (*DriverSingIn, error)
+}
+
+// TokenClient asks the user service to issue a token pair for a driver, so
+// both services share one token issuer.
+type TokenClient interface {
+	GetJWT(ctx context.Context, id uint64) (*Token, error)
+}
+
+type AuthService struct {
+	AuthRepo
+	TokenClient
+	keys *JWKS
+	cfg  *config.Config
+}
+
+func NewAuthSevice(postgres AuthRepo, client TokenClient, keys *JWKS, cfg *config.Config) *AuthService {
+	return &AuthService{postgres, client, keys, cfg}
+}
+
+// Verify verifies the driver's access token with the keys of the user service.
+func (s *AuthService) Verify(token string) (uint64, error) {
+	return Verify(token, s.keys)
+}
+
+func (s *AuthService) SingUp(ctx context.Context, driver DriverSingUp) error {
+	switch driver.TaxiType {
+	case model.TaxiTypeEconomy, model.TaxiTypeComfort, model.TaxiTypeBusiness:
+	default:
+		return fmt.Errorf("taxi type: %v: %w", driver.TaxiType, ErrUnknownTaxiType)
+	}
+
+	driver.Name = NormalizeName(driver.Name)
+	driver.PhoneNumber = NormalizePhone(driver.PhoneNumber)
+	driver.Email = NormalizeEmail(driver.Email)
+
+	var err error
+	driver.Password, err = s.GenerateHash(driver.Password)
+	if err != nil {
+		return fmt.Errorf("generate hash failed: %w", err)
+	}
+
+	err = s.CreateDriver(ctx, driver)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *AuthService) GenerateHash(password string) (string, error) {
+	return hashPassword(password)
+}
+
+// ComparePassword reports whether password matches the stored hash.
+func (s *AuthService) ComparePassword(hash, password string) (bool, error) {
+	return comparePassword(hash, password)
+}
+
+func (s *AuthService) SingIn(ctx context.Context, driver DriverSingIn) (*Token, error) {
+	driver.PhoneNumber = NormalizePhone(driver.PhoneNumber)
+
+	driverDB, err := s.CheckDriverByPhoneNumber(ctx, driver.PhoneNumber)
+	if err != nil {
+		// An unknown number fails like a wrong password, so sign in doesn't
+		// tell who has an account.
+		if errors.Is(err, ErrDriverDoesNotExists) {
+			return nil, ErrIncorrectPassword
+		}
+		return nil, fmt.Errorf("check driver by phone number failed: %w", err)
+	}
+
+	ok, err := s.ComparePassword(driverDB.Password, driver.Password)
+	if err != nil {
+		return nil, fmt.Errorf("compare password failed: %w", err)
+	}
+	if !ok {
+		return nil, ErrIncorrectPassword
+	}
+
+	token, err := s.GetJWT(ctx, driverDB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get jwt failed: %w", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,237 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/RipperAcskt/innotaxi-driver/internal/model"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service/mocks"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSingUp(t *testing.T) {
+	type mockBehavior func(s *mocks.MockAuthRepo, driver service.DriverSingUp, hashed *string)
+	test := []struct {
+		name         string
+		driver       service.DriverSingUp
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name: "correct driver",
+			driver: service.DriverSingUp{
+				Name:        " Ivan ",
+				PhoneNumber: "+7 (900) 123-45-67",
+				Email:       "Ripper@Mail.ru",
+				Password:    "Qwerty123",
+				TaxiType:    model.TaxiTypeEconomy,
+			},
+			mockBehavior: func(s *mocks.MockAuthRepo, driver service.DriverSingUp, hashed *string) {
+				s.EXPECT().CreateDriver(context.Background(), gomock.Any()).DoAndReturn(func(ctx context.Context, d service.DriverSingUp) error {
+					assert.Equal(t, d.Name, "Ivan")
+					assert.Equal(t, d.PhoneNumber, "+79001234567")
+					assert.Equal(t, d.Email, "ripper@mail.ru")
+					*hashed = d.Password
+					return nil
+				})
+			},
+			err: nil,
+		},
+		{
+			name: "unknown taxi type",
+			driver: service.DriverSingUp{
+				Name:        "Ivan",
+				PhoneNumber: "+79001234567",
+				Email:       "ripper@mail.ru",
+				Password:    "Qwerty123",
+				TaxiType:    "bus",
+			},
+			mockBehavior: func(s *mocks.MockAuthRepo, driver service.DriverSingUp, hashed *string) {},
+			err:          service.ErrUnknownTaxiType,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authRepo := mocks.NewMockAuthRepo(ctrl)
+			authService := service.NewAuthSevice(authRepo, mocks.NewMockTokenClient(ctrl), nil, &config.Config{})
+
+			var hashed string
+			tt.mockBehavior(authRepo, tt.driver, &hashed)
+
+			err := authService.SingUp(context.Background(), tt.driver)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+
+			if tt.err == nil {
+				ok, err := authService.ComparePassword(hashed, tt.driver.Password)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, ok, true)
+			}
+		})
+	}
+}
+
+func TestSingIn(t *testing.T) {
+	type mockBehavior func(s *mocks.MockAuthRepo, c *mocks.MockTokenClient)
+
+	argon2id, _ := service.NewAuthSevice(nil, nil, nil, &config.Config{}).GenerateHash("2")
+
+	test := []struct {
+		name         string
+		driver       service.DriverSingIn
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name: "correct password",
+			driver: service.DriverSingIn{
+				PhoneNumber: "2",
+				Password:    "2",
+			},
+			mockBehavior: func(s *mocks.MockAuthRepo, c *mocks.MockTokenClient) {
+				s.EXPECT().CheckDriverByPhoneNumber(context.Background(), "2").Return(&service.DriverSingIn{
+					ID:          9,
+					PhoneNumber: "2",
+					Password:    argon2id,
+				}, nil)
+				c.EXPECT().GetJWT(context.Background(), uint64(9)).Return(&service.Token{Access: "a"}, nil)
+			},
+			err: nil,
+		},
+		{
+			name: "incorrect password",
+			driver: service.DriverSingIn{
+				PhoneNumber: "2",
+				Password:    "3",
+			},
+			mockBehavior: func(s *mocks.MockAuthRepo, c *mocks.MockTokenClient) {
+				s.EXPECT().CheckDriverByPhoneNumber(context.Background(), "2").Return(&service.DriverSingIn{
+					ID:          9,
+					PhoneNumber: "2",
+					Password:    argon2id,
+				}, nil)
+			},
+			err: service.ErrIncorrectPassword,
+		},
+		{
+			name: "unknown hash format",
+			driver: service.DriverSingIn{
+				PhoneNumber: "2",
+				Password:    "2",
+			},
+			mockBehavior: func(s *mocks.MockAuthRepo, c *mocks.MockTokenClient) {
+				s.EXPECT().CheckDriverByPhoneNumber(context.Background(), "2").Return(&service.DriverSingIn{
+					ID:          9,
+					PhoneNumber: "2",
+					Password:    "$2a$04$abc",
+				}, nil)
+			},
+			err: service.ErrUnknownHashFormat,
+		},
+		{
+			name: "unknown phone number",
+			driver: service.DriverSingIn{
+				PhoneNumber: "2",
+				Password:    "2",
+			},
+			mockBehavior: func(s *mocks.MockAuthRepo, c *mocks.MockTokenClient) {
+				s.EXPECT().CheckDriverByPhoneNumber(context.Background(), "2").Return(nil, service.ErrDriverDoesNotExists)
+			},
+			err: service.ErrIncorrectPassword,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authRepo := mocks.NewMockAuthRepo(ctrl)
+			tokenClient := mocks.NewMockTokenClient(ctrl)
+			authService := service.NewAuthSevice(authRepo, tokenClient, nil, &config.Config{})
+
+			tt.mockBehavior(authRepo, tokenClient)
+
+			_, err := authService.SingIn(context.Background(), tt.driver)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	cfg := &config.Config{
+		HS256_SECRET: "QWERTfg53gxb2",
+	}
+
+	newToken := func(id any, tokenType string, exp time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"user_id":   id,
+			"type":      tokenType,
+			"token_use": service.TokenUseAccess,
+			"iss":       service.Issuer,
+			"aud":       service.Audience,
+			"exp":       exp.Unix(),
+		})
+		s, _ := token.SignedString([]byte(cfg.HS256_SECRET))
+		return s
+	}
+
+	test := []struct {
+		name     string
+		token    string
+		driverId uint64
+		err      error
+	}{
+		{
+			name:     "verify token ok",
+			token:    newToken("7", service.Driver, time.Now().Add(time.Hour)),
+			driverId: 7,
+			err:      nil,
+		},
+		{
+			name:     "verify token expired",
+			token:    newToken("7", service.Driver, time.Now().Add(-time.Hour)),
+			driverId: 0,
+			err:      service.ErrTokenExpired,
+		},
+		{
+			name: "verify refresh token",
+			token: func() string {
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"user_id":   "7",
+					"type":      service.Driver,
+					"token_use": "refresh",
+					"iss":       service.Issuer,
+					"aud":       service.Audience,
+					"exp":       time.Now().Add(time.Hour).Unix(),
+				}).SignedString([]byte(cfg.HS256_SECRET))
+				return token
+			}(),
+			driverId: 0,
+			err:      service.ErrWrongTokenUse,
+		},
+		{
+			name:     "verify user token",
+			token:    newToken(7, service.User, time.Now().Add(time.Hour)),
+			driverId: 0,
+			err:      service.ErrUnknownType,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := service.Verify(tt.token, service.NewJWKS(cfg))
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			assert.Equal(t, id, tt.driverId)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/RipperAcskt/innotaxi-driver/internal/model"
+)
+
+var (
+	ErrUnknownStatus      = fmt.Errorf("unknown status")
+	ErrFreeDriverNotFound = fmt.Errorf("free driver not found")
+	ErrOrderNotFound      = fmt.Errorf("order not found")
+	ErrIncorrectRating    = fmt.Errorf("rating must be from 1 to 5")
+)
+
+// freeDriversBuffer is how many free drivers a slow subscriber may lag behind
+// before new ones are dropped for it.
+const freeDriversBuffer = 64
+
+type DriverStatus struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type DriverRepo interface {
+	UpdateStatusById(ctx context.Context, id string, status string) (string, error)
+	TakeFreeDriver(ctx context.Context, order *model.Order) error
+	GetOrdersByDriverId(ctx context.Context, id string) ([]*model.Order, error)
+	GetRatingById(ctx context.Context, id string) (float64, error)
+	// RateLastOrder sets the rating of the last finished order of the driver
+	// with the user. It returns ErrOrderNotFound if they have none.
+	RateLastOrder(ctx context.Context, driverID string, userID uint64, rating int) error
+	// ReleaseDriver drops the driver's order in progress with the user and
+	// makes the driver free. It returns ErrOrderNotFound if there is none.
+	ReleaseDriver(ctx context.Context, driverID string, userID uint64) (string, error)
+}
+
+type DriverService struct {
+	DriverRepo
+	mu          sync.Mutex
+	subscribers map[chan model.Driver]struct{}
+}
+
+func NewDriverService(postgres DriverRepo) *DriverService {
+	return &DriverService{
+		DriverRepo:  postgres,
+		subscribers: make(map[chan model.Driver]struct{}),
+	}
+}
+
+func (d *DriverService) ChangeStatus(ctx context.Context, id string, status DriverStatus) error {
+	if status.Status != model.StatusFree && status.Status != model.StatusBusy {
+		return fmt.Errorf("status: %v: %w", status.Status, ErrUnknownStatus)
+	}
+
+	driverID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse uint failed: %w", err)
+	}
+
+	taxiType, err := d.UpdateStatusById(ctx, id, status.Status)
+	if err != nil {
+		return err
+	}
+
+	if status.Status == model.StatusFree {
+		d.publish(model.Driver{ID: driverID, TaxiType: taxiType, Status: model.StatusFree})
+	}
+	return nil
+}
+
+// MatchDriver takes a free driver of the order's taxi type, marks them busy
+// and starts the order for them.
+func (d *DriverService) MatchDriver(ctx context.Context, order *model.Order) error {
+	return d.TakeFreeDriver(ctx, order)
+}
+
+// SubscribeFreeDrivers returns a channel of drivers which became free and a
+// function to cancel the subscription.
+func (d *DriverService) SubscribeFreeDrivers() (<-chan model.Driver, func()) {
+	ch := make(chan model.Driver, freeDriversBuffer)
+
+	d.mu.Lock()
+	d.subscribers[ch] = struct{}{}
+	d.mu.Unlock()
+
+	return ch, func() {
+		d.mu.Lock()
+		delete(d.subscribers, ch)
+		d.mu.Unlock()
+	}
+}
+
+func (d *DriverService) publish(driver model.Driver) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for ch := range d.subscribers {
+		select {
+		case ch <- driver:
+		default:
+		}
+	}
+}
+
+func (d *DriverService) GetOrders(ctx context.Context, id string) ([]*model.Order, error) {
+	return d.GetOrdersByDriverId(ctx, id)
+}
+
+func (d *DriverService) GetRating(ctx context.Context, id string) (float64, error) {
+	return d.GetRatingById(ctx, id)
+}
+
+// RateOrder stores the rating the user gave to the driver's trip, the
+// driver's rating is the average of them. The user service checks the rating
+// window and that the trip is rated once, rating it again overwrites the
+// rating.
+func (d *DriverService) RateOrder(ctx context.Context, driverID string, userID uint64, rating int) error {
+	if rating < 1 || rating > 5 {
+		return ErrIncorrectRating
+	}
+	return d.RateLastOrder(ctx, driverID, userID, rating)
+}
+
+// ReleaseDriver undoes MatchDriver when the user service fails to store the
+// order, so the driver doesn't stay busy with a trip nobody knows about.
+func (d *DriverService) ReleaseDriver(ctx context.Context, driverID string, userID uint64) error {
+	id, err := strconv.ParseUint(driverID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse uint failed: %w", err)
+	}
+
+	taxiType, err := d.DriverRepo.ReleaseDriver(ctx, driverID, userID)
+	if err != nil {
+		return err
+	}
+
+	d.publish(model.Driver{ID: id, TaxiType: taxiType, Status: model.StatusFree})
+	return nil
+}
@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi-driver/internal/model"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service/mocks"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+)
+
+func TestChangeStatus(t *testing.T) {
+	type mockBehavior func(s *mocks.MockDriverRepo)
+	test := []struct {
+		name         string
+		status       service.DriverStatus
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:   "free",
+			status: service.DriverStatus{Status: model.StatusFree},
+			mockBehavior: func(s *mocks.MockDriverRepo) {
+				s.EXPECT().UpdateStatusById(context.Background(), "1", model.StatusFree).Return(model.TaxiTypeEconomy, nil)
+			},
+			err: nil,
+		},
+		{
+			name:         "unknown status",
+			status:       service.DriverStatus{Status: "sleeping"},
+			mockBehavior: func(s *mocks.MockDriverRepo) {},
+			err:          service.ErrUnknownStatus,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			driverRepo := mocks.NewMockDriverRepo(ctrl)
+			tt.mockBehavior(driverRepo)
+
+			driverService := service.NewDriverService(driverRepo)
+
+			err := driverService.ChangeStatus(context.Background(), "1", tt.status)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
+
+func TestGetRating(t *testing.T) {
+	type mockBehavior func(s *mocks.MockDriverRepo)
+	test := []struct {
+		name         string
+		mockBehavior mockBehavior
+		rating       float64
+		err          error
+	}{
+		{
+			name: "get rating",
+			mockBehavior: func(s *mocks.MockDriverRepo) {
+				s.EXPECT().GetRatingById(context.Background(), "1").Return(4.5, nil)
+			},
+			rating: 4.5,
+			err:    nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			driverRepo := mocks.NewMockDriverRepo(ctrl)
+			tt.mockBehavior(driverRepo)
+
+			driverService := service.NewDriverService(driverRepo)
+
+			rating, err := driverService.GetRating(context.Background(), "1")
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, rating, tt.rating)
+		})
+	}
+}
+
+func TestRateOrder(t *testing.T) {
+	type mockBehavior func(s *mocks.MockDriverRepo)
+	test := []struct {
+		name         string
+		rating       int
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:   "rate order",
+			rating: 5,
+			mockBehavior: func(s *mocks.MockDriverRepo) {
+				s.EXPECT().RateLastOrder(context.Background(), "1", uint64(2), 5).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:         "incorrect rating",
+			rating:       0,
+			mockBehavior: func(s *mocks.MockDriverRepo) {},
+			err:          service.ErrIncorrectRating,
+		},
+		{
+			name:   "no finished order",
+			rating: 4,
+			mockBehavior: func(s *mocks.MockDriverRepo) {
+				s.EXPECT().RateLastOrder(context.Background(), "1", uint64(2), 4).Return(service.ErrOrderNotFound)
+			},
+			err: service.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			driverRepo := mocks.NewMockDriverRepo(ctrl)
+			tt.mockBehavior(driverRepo)
+
+			driverService := service.NewDriverService(driverRepo)
+
+			err := driverService.RateOrder(context.Background(), "1", 2, tt.rating)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
+
+func TestSubscribeFreeDrivers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	driverRepo := mocks.NewMockDriverRepo(ctrl)
+	driverRepo.EXPECT().UpdateStatusById(context.Background(), "1", model.StatusFree).Return(model.TaxiTypeBusiness, nil)
+	driverRepo.EXPECT().UpdateStatusById(context.Background(), "2", model.StatusBusy).Return(model.TaxiTypeBusiness, nil)
+
+	driverService := service.NewDriverService(driverRepo)
+	drivers, cancel := driverService.SubscribeFreeDrivers()
+	defer cancel()
+
+	err := driverService.ChangeStatus(context.Background(), "2", service.DriverStatus{Status: model.StatusBusy})
+	assert.Equal(t, err, nil)
+	err = driverService.ChangeStatus(context.Background(), "1", service.DriverStatus{Status: model.StatusFree})
+	assert.Equal(t, err, nil)
+
+	driver := <-drivers
+	assert.Equal(t, driver.ID, uint64(1))
+	assert.Equal(t, driver.TaxiType, model.TaxiTypeBusiness)
+	assert.Equal(t, len(drivers), 0)
+}
+
+func TestReleaseDriver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	driverRepo := mocks.NewMockDriverRepo(ctrl)
+	driverRepo.EXPECT().ReleaseDriver(context.Background(), "1", uint64(2)).Return(model.TaxiTypeComfort, nil)
+	driverRepo.EXPECT().ReleaseDriver(context.Background(), "3", uint64(2)).Return("", service.ErrOrderNotFound)
+
+	driverService := service.NewDriverService(driverRepo)
+	drivers, cancel := driverService.SubscribeFreeDrivers()
+	defer cancel()
+
+	err := driverService.ReleaseDriver(context.Background(), "3", 2)
+	assert.Equal(t, errors.Is(err, service.ErrOrderNotFound), true)
+	err = driverService.ReleaseDriver(context.Background(), "1", 2)
+	assert.Equal(t, err, nil)
+
+	driver := <-drivers
+	assert.Equal(t, driver.ID, uint64(1))
+	assert.Equal(t, driver.TaxiType, model.TaxiTypeComfort)
+	assert.Equal(t, len(drivers), 0)
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestComparePassword(t *testing.T) {
+	authService := service.NewAuthSevice(nil, nil, nil, &config.Config{})
+	hash, err := authService.GenerateHash("12345")
+	assert.Equal(t, err, nil)
+
+	test := []struct {
+		name     string
+		hash     string
+		password string
+		ok       bool
+		err      error
+	}{
+		{
+			name:     "correct password",
+			hash:     hash,
+			password: "12345",
+			ok:       true,
+			err:      nil,
+		},
+		{
+			name:     "incorrect password",
+			hash:     hash,
+			password: "54321",
+			ok:       false,
+			err:      nil,
+		},
+		{
+			name:     "other params",
+			hash:     "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$fP3z1mRo6GuoP0Vmc8cTcWq60gZKgI0wuo6kPwr/bHg",
+			password: "wrong",
+			ok:       false,
+			err:      nil,
+		},
+		{
+			name:     "bcrypt hash",
+			hash:     "$2a$04$abc",
+			password: "12345",
+			ok:       false,
+			err:      service.ErrUnknownHashFormat,
+		},
+		{
+			name:     "broken params",
+			hash:     "$argon2id$v=19$m=1024,t=1,p=0$c2FsdHNhbHQ$fP3z1mRo6GuoP0Vmc8cTcWq60gZKgI0wuo6kPwr/bHg",
+			password: "12345",
+			ok:       false,
+			err:      service.ErrUnknownHashFormat,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := authService.ComparePassword(tt.hash, tt.password)
+			assert.Equal(t, ok, tt.ok)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
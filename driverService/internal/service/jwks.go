@@ -0,0 +1,169 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/golang-jwt/jwt"
+)
+
+const jwksRefreshInterval = time.Minute
+
+var ErrUnknownKey = fmt.Errorf("unknown key")
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type publicKey struct {
+	method jwt.SigningMethod
+	key    interface{}
+}
+
+// JWKS verifies tokens with the public keys the user service publishes at
+// JWKS_URL, so the driver service doesn't need the private keys. The keys are
+// fetched again when a token has an unknown kid, but not more often than once
+// a minute. Tokens without kid are checked with the HS256 secret if it's set.
+type JWKS struct {
+	url    string
+	secret []byte
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]publicKey
+	fetched time.Time
+}
+
+func NewJWKS(cfg *config.Config) *JWKS {
+	return &JWKS{
+		url:    cfg.JWKS_URL,
+		secret: []byte(cfg.HS256_SECRET),
+		client: &http.Client{Timeout: 5 * time.Second},
+		keys:   make(map[string]publicKey),
+	}
+}
+
+func (k *JWKS) keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		if token.Method != jwt.SigningMethodHS256 || len(k.secret) == 0 {
+			return nil, ErrUnknownKey
+		}
+		return k.secret, nil
+	}
+
+	key, err := k.get(kid)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method != key.method {
+		return nil, fmt.Errorf("alg %v: %w", token.Method.Alg(), ErrUnknownKey)
+	}
+	return key.key, nil
+}
+
+func (k *JWKS) get(kid string) (publicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	key, ok := k.keys[kid]
+	if ok {
+		return key, nil
+	}
+
+	if k.url == "" || time.Since(k.fetched) < jwksRefreshInterval {
+		return publicKey{}, fmt.Errorf("kid %v: %w", kid, ErrUnknownKey)
+	}
+
+	err := k.fetch()
+	if err != nil {
+		return publicKey{}, fmt.Errorf("fetch failed: %w", err)
+	}
+
+	key, ok = k.keys[kid]
+	if !ok {
+		return publicKey{}, fmt.Errorf("kid %v: %w", kid, ErrUnknownKey)
+	}
+	return key, nil
+}
+
+func (k *JWKS) fetch() error {
+	k.fetched = time.Now()
+
+	res, err := k.client.Get(k.url)
+	if err != nil {
+		return fmt.Errorf("get failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %v", res.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&set)
+	if err != nil {
+		return fmt.Errorf("decode failed: %w", err)
+	}
+
+	// Keys of unsupported types are skipped, they can't verify tokens anyway.
+	keys := make(map[string]publicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	k.keys = keys
+	return nil
+}
+
+func parseJWK(key jwk) (publicKey, error) {
+	switch {
+	case key.Kty == "RSA" && key.Alg == jwt.SigningMethodRS256.Alg():
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("decode n failed: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("decode e failed: %w", err)
+		}
+
+		public := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		return publicKey{jwt.SigningMethodRS256, public}, nil
+
+	case key.Kty == "OKP" && key.Crv == "Ed25519" && key.Alg == jwt.SigningMethodEdDSA.Alg():
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("decode x failed: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return publicKey{}, fmt.Errorf("wrong key size: %v", len(x))
+		}
+		return publicKey{jwt.SigningMethodEdDSA, ed25519.PublicKey(x)}, nil
+	}
+
+	return publicKey{}, fmt.Errorf("unsupported key: %v %v", key.Kty, key.Alg)
+}
@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+	"github.com/RipperAcskt/innotaxi-driver/internal/service"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang-jwt/jwt"
+)
+
+func TestVerifyJWKS(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPublic, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"keys": [` +
+			`{"kty": "RSA", "kid": "rs-1", "use": "sig", "alg": "RS256", "n": "` + base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()) +
+			`", "e": "` + base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()) + `"},` +
+			`{"kty": "OKP", "kid": "ed-1", "use": "sig", "alg": "EdDSA", "crv": "Ed25519", "x": "` + base64.RawURLEncoding.EncodeToString(edPublic) + `"}` +
+			`]}`))
+	}))
+	defer server.Close()
+
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"user_id":   "7",
+			"type":      service.Driver,
+			"token_use": service.TokenUseAccess,
+			"iss":       service.Issuer,
+			"aud":       service.Audience,
+			"exp":       time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	newToken := func(method jwt.SigningMethod, kid string, key interface{}) string {
+		token := jwt.NewWithClaims(method, claims())
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, _ := token.SignedString(key)
+		return s
+	}
+
+	test := []struct {
+		name     string
+		token    string
+		driverId uint64
+		err      error
+	}{
+		{
+			name:     "rs256",
+			token:    newToken(jwt.SigningMethodRS256, "rs-1", rsaKey),
+			driverId: 7,
+			err:      nil,
+		},
+		{
+			name:     "eddsa",
+			token:    newToken(jwt.SigningMethodEdDSA, "ed-1", edKey),
+			driverId: 7,
+			err:      nil,
+		},
+		{
+			name:     "unknown kid",
+			token:    newToken(jwt.SigningMethodEdDSA, "ed-2", edKey),
+			driverId: 0,
+			err:      service.ErrInvalidToken,
+		},
+		{
+			name:     "wrong alg for kid",
+			token:    newToken(jwt.SigningMethodHS256, "ed-1", []byte(edPublic)),
+			driverId: 0,
+			err:      service.ErrInvalidToken,
+		},
+		{
+			name:     "hs256 without secret",
+			token:    newToken(jwt.SigningMethodHS256, "", []byte("QWERTfg53gxb2")),
+			driverId: 0,
+			err:      service.ErrInvalidToken,
+		},
+	}
+
+	keys := service.NewJWKS(&config.Config{JWKS_URL: server.URL})
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := service.Verify(tt.token, keys)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			assert.Equal(t, id, tt.driverId)
+		})
+	}
+
+	// Unknown kids don't make the keys be fetched more than once a minute.
+	assert.Equal(t, requests, 1)
+}
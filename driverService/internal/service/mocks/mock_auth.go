@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi-driver/internal/service (interfaces: AuthRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/RipperAcskt/innotaxi-driver/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthRepo is a mock of AuthRepo interface.
+type MockAuthRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthRepoMockRecorder
+}
+
+// MockAuthRepoMockRecorder is the mock recorder for MockAuthRepo.
+type MockAuthRepoMockRecorder struct {
+	mock *MockAuthRepo
+}
+
+// NewMockAuthRepo creates a new mock instance.
+func NewMockAuthRepo(ctrl *gomock.Controller) *MockAuthRepo {
+	mock := &MockAuthRepo{ctrl: ctrl}
+	mock.recorder = &MockAuthRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthRepo) EXPECT() *MockAuthRepoMockRecorder {
+	return m.recorder
+}
+
+// CheckDriverByPhoneNumber mocks base method.
+func (m *MockAuthRepo) CheckDriverByPhoneNumber(arg0 context.Context, arg1 string) (*service.DriverSingIn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDriverByPhoneNumber", arg0, arg1)
+	ret0, _ := ret[0].(*service.DriverSingIn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDriverByPhoneNumber indicates an expected call of CheckDriverByPhoneNumber.
+func (mr *MockAuthRepoMockRecorder) CheckDriverByPhoneNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDriverByPhoneNumber", reflect.TypeOf((*MockAuthRepo)(nil).CheckDriverByPhoneNumber), arg0, arg1)
+}
+
+// CreateDriver mocks base method.
+func (m *MockAuthRepo) CreateDriver(arg0 context.Context, arg1 service.DriverSingUp) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDriver", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDriver indicates an expected call of CreateDriver.
+func (mr *MockAuthRepoMockRecorder) CreateDriver(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDriver", reflect.TypeOf((*MockAuthRepo)(nil).CreateDriver), arg0, arg1)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi-driver/internal/service (interfaces: DriverRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/RipperAcskt/innotaxi-driver/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDriverRepo is a mock of DriverRepo interface.
+type MockDriverRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriverRepoMockRecorder
+}
+
+// MockDriverRepoMockRecorder is the mock recorder for MockDriverRepo.
+type MockDriverRepoMockRecorder struct {
+	mock *MockDriverRepo
+}
+
+// NewMockDriverRepo creates a new mock instance.
+func NewMockDriverRepo(ctrl *gomock.Controller) *MockDriverRepo {
+	mock := &MockDriverRepo{ctrl: ctrl}
+	mock.recorder = &MockDriverRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriverRepo) EXPECT() *MockDriverRepoMockRecorder {
+	return m.recorder
+}
+
+// GetOrdersByDriverId mocks base method.
+func (m *MockDriverRepo) GetOrdersByDriverId(arg0 context.Context, arg1 string) ([]*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByDriverId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByDriverId indicates an expected call of GetOrdersByDriverId.
+func (mr *MockDriverRepoMockRecorder) GetOrdersByDriverId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByDriverId", reflect.TypeOf((*MockDriverRepo)(nil).GetOrdersByDriverId), arg0, arg1)
+}
+
+// GetRatingById mocks base method.
+func (m *MockDriverRepo) GetRatingById(arg0 context.Context, arg1 string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingById", arg0, arg1)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingById indicates an expected call of GetRatingById.
+func (mr *MockDriverRepoMockRecorder) GetRatingById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingById", reflect.TypeOf((*MockDriverRepo)(nil).GetRatingById), arg0, arg1)
+}
+
+// RateLastOrder mocks base method.
+func (m *MockDriverRepo) RateLastOrder(arg0 context.Context, arg1 string, arg2 uint64, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLastOrder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RateLastOrder indicates an expected call of RateLastOrder.
+func (mr *MockDriverRepoMockRecorder) RateLastOrder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLastOrder", reflect.TypeOf((*MockDriverRepo)(nil).RateLastOrder), arg0, arg1, arg2, arg3)
+}
+
+// ReleaseDriver mocks base method.
+func (m *MockDriverRepo) ReleaseDriver(arg0 context.Context, arg1 string, arg2 uint64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDriver", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseDriver indicates an expected call of ReleaseDriver.
+func (mr *MockDriverRepoMockRecorder) ReleaseDriver(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDriver", reflect.TypeOf((*MockDriverRepo)(nil).ReleaseDriver), arg0, arg1, arg2)
+}
+
+// TakeFreeDriver mocks base method.
+func (m *MockDriverRepo) TakeFreeDriver(arg0 context.Context, arg1 *model.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeFreeDriver", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeFreeDriver indicates an expected call of TakeFreeDriver.
+func (mr *MockDriverRepoMockRecorder) TakeFreeDriver(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeFreeDriver", reflect.TypeOf((*MockDriverRepo)(nil).TakeFreeDriver), arg0, arg1)
+}
+
+// UpdateStatusById mocks base method.
+func (m *MockDriverRepo) UpdateStatusById(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatusById indicates an expected call of UpdateStatusById.
+func (mr *MockDriverRepoMockRecorder) UpdateStatusById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusById", reflect.TypeOf((*MockDriverRepo)(nil).UpdateStatusById), arg0, arg1, arg2)
+}
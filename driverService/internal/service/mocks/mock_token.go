@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi-driver/internal/service (interfaces: TokenClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/RipperAcskt/innotaxi-driver/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenClient is a mock of TokenClient interface.
+type MockTokenClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenClientMockRecorder
+}
+
+// MockTokenClientMockRecorder is the mock recorder for MockTokenClient.
+type MockTokenClientMockRecorder struct {
+	mock *MockTokenClient
+}
+
+// NewMockTokenClient creates a new mock instance.
+func NewMockTokenClient(ctrl *gomock.Controller) *MockTokenClient {
+	mock := &MockTokenClient{ctrl: ctrl}
+	mock.recorder = &MockTokenClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenClient) EXPECT() *MockTokenClientMockRecorder {
+	return m.recorder
+}
+
+// GetJWT mocks base method.
+func (m *MockTokenClient) GetJWT(arg0 context.Context, arg1 uint64) (*service.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWT", arg0, arg1)
+	ret0, _ := ret[0].(*service.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJWT indicates an expected call of GetJWT.
+func (mr *MockTokenClientMockRecorder) GetJWT(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWT", reflect.TypeOf((*MockTokenClient)(nil).GetJWT), arg0, arg1)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/RipperAcskt/innotaxi-driver/config"
+)
+
+//go:generate mockgen -destination=mocks/mock_auth.go -package=mocks github.com/RipperAcskt/innotaxi-driver/internal/service AuthRepo
+//go:generate mockgen -destination=mocks/mock_token.go -package=mocks github.com/RipperAcskt/innotaxi-driver/internal/service TokenClient
+//go:generate mockgen -destination=mocks/mock_driver.go -package=mocks github.com/RipperAcskt/innotaxi-driver/internal/service DriverRepo
+type Service struct {
+	*AuthService
+	*DriverService
+}
+type Repo interface {
+	AuthRepo
+	DriverRepo
+}
+
+func New(postgres Repo, client TokenClient, keys *JWKS, cfg *config.Config) *Service {
+	return &Service{
+		AuthService:   NewAuthSevice(postgres, client, keys, cfg),
+		DriverService: NewDriverService(postgres),
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	User   = "user"
+	Driver = "driver"
+)
+
+// Tokens are issued by the user service, so the claims must match the ones
+// it writes.
+const (
+	TokenUseAccess = "access"
+
+	Issuer   = "innotaxi-user"
+	Audience = "innotaxi"
+)
+
+var (
+	ErrTokenExpired  = fmt.Errorf("token expired")
+	ErrUnknownType   = fmt.Errorf("unknown type")
+	ErrWrongTokenUse = fmt.Errorf("wrong token use")
+	ErrInvalidToken  = fmt.Errorf("invalid token")
+)
+
+// Token is the access token the user service issues for a driver. Drivers
+// get no refresh token and sign in again when it expires.
+type Token struct {
+	Access string `json:"access_token"`
+}
+
+// Verify verifies the driver's access token. Refresh tokens are rejected.
+func Verify(token string, keys *JWKS) (uint64, error) {
+	tokenJwt, err := jwt.Parse(token, keys.keyfunc)
+
+	if err != nil {
+		var ve *jwt.ValidationError
+		if errors.As(err, &ve) && ve.Errors&jwt.ValidationErrorExpired != 0 {
+			return 0, ErrTokenExpired
+		}
+		if errors.As(err, &ve) && errors.Is(ve.Inner, ErrUnknownKey) {
+			return 0, fmt.Errorf("%v: %w", ve.Inner, ErrInvalidToken)
+		}
+		return 0, fmt.Errorf("token parse failed: %w", err)
+	}
+
+	claims, ok := tokenJwt.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, fmt.Errorf("jwt map claims failed")
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().UTC().Unix(), true) {
+		return 0, ErrTokenExpired
+	}
+	if !claims.VerifyIssuer(Issuer, true) || !claims.VerifyAudience(Audience, true) {
+		return 0, ErrInvalidToken
+	}
+	if claims["token_use"] != TokenUseAccess {
+		return 0, ErrWrongTokenUse
+	}
+	if claims["type"] != Driver {
+		return 0, ErrUnknownType
+	}
+
+	// The user service issues driver tokens with the id passed as a string.
+	switch id := claims["user_id"].(type) {
+	case string:
+		return strconv.ParseUint(id, 10, 64)
+	case float64:
+		return uint64(id), nil
+	}
+	return 0, fmt.Errorf("unknown id type")
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The limits of the drivers columns and of the passwords.
+const (
+	maxNameLen     = 30
+	maxEmailLen    = 30
+	minPasswordLen = 8
+	maxPasswordLen = 72
+)
+
+var (
+	e164 = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+	phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+)
+
+// NormalizePhone drops the spaces, dashes, dots and parentheses of phone and
+// replaces the international prefix 00 with +, so numbers written in the
+// usual ways become E.164.
+func NormalizePhone(phone string) string {
+	phone = phoneSeparators.Replace(strings.TrimSpace(phone))
+	if strings.HasPrefix(phone, "00") {
+		phone = "+" + phone[2:]
+	}
+	return phone
+}
+
+// NormalizeEmail trims and lowercases email, so the same address can't be
+// used by two drivers in different case.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizeName trims name and collapses the runs of spaces in it.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// ValidPhone reports whether phone is an E.164 number.
+func ValidPhone(phone string) bool {
+	return e164.MatchString(phone)
+}
+
+// ValidEmail reports whether email is a bare RFC 5322 address which fits
+// the drivers column.
+func ValidEmail(email string) bool {
+	if len(email) > maxEmailLen {
+		return false
+	}
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Name == "" && addr.Address == email
+}
+
+// ValidName reports whether name is up to 30 letters, spaces, hyphens and
+// apostrophes and starts with a letter.
+func ValidName(name string) bool {
+	if name == "" || utf8.RuneCountInString(name) > maxNameLen {
+		return false
+	}
+	for i, r := range name {
+		if unicode.IsLetter(r) {
+			continue
+		}
+		if i == 0 || (r != ' ' && r != '-' && r != '\'') {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidPassword reports whether password is 8 to 72 bytes long and has a
+// lowercase letter, an uppercase letter and a digit.
+func ValidPassword(password string) bool {
+	if utf8.RuneCountInString(password) < minPasswordLen || len(password) > maxPasswordLen {
+		return false
+	}
+
+	var lower, upper, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return lower && upper && digit
+}
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+
+	"github.com/RipperAcskt/innotaxi-driver/config"
+)
+
+// Exporters of TRACE_EXPORTER. Tracing is off if it's empty.
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+const (
+	ServiceName = "innotaxi-driver"
+
+	defaultOTLPEndpoint = "localhost:4317"
+)
+
+var ErrUnknownExporter = fmt.Errorf("unknown exporter")
+
+// New sets the global tracer provider exporting to TRACE_EXPORTER and the
+// W3C trace context and baggage propagator, so the spans of the requests
+// join the traces of their callers. The returned func flushes the spans left
+// and stops the provider.
+func New(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.TRACE_EXPORTER {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		endpoint := cfg.TRACE_OTLP_ENDPOINT
+		if endpoint == "" {
+			endpoint = defaultOTLPEndpoint
+		}
+
+		var err error
+		exporter, err = otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(endpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("otlp trace grpc new failed: %w", err)
+		}
+	case ExporterStdout:
+		var err error
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("stdout trace new failed: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("exporter %v: %w", cfg.TRACE_EXPORTER, ErrUnknownExporter)
+	}
+
+	provider := NewProvider(exporter)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewProvider returns a provider sampling every trace which isn't sampled
+// out by its caller and exporting the spans in batches.
+func NewProvider(exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName))),
+	)
+}
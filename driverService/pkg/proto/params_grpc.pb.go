@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: params.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthServiceClient interface {
+	GetJWT(ctx context.Context, in *Params, opts ...grpc.CallOption) (*Response, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) GetJWT(ctx context.Context, in *Params, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/AuthService/GetJWT", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations should embed UnimplementedAuthServiceServer
+// for forward compatibility
+type AuthServiceServer interface {
+	GetJWT(context.Context, *Params) (*Response, error)
+}
+
+// UnimplementedAuthServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedAuthServiceServer struct {
+}
+
+func (UnimplementedAuthServiceServer) GetJWT(context.Context, *Params) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJWT not implemented")
+}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_GetJWT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Params)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetJWT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuthService/GetJWT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetJWT(ctx, req.(*Params))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetJWT",
+			Handler:    _AuthService_GetJWT_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "params.proto",
+}
+
+// DriverMatchingClient is the client API for DriverMatching service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DriverMatchingClient interface {
+	FindFreeDriver(ctx context.Context, in *FindDriverRequest, opts ...grpc.CallOption) (*FindDriverResponse, error)
+	WatchFreeDrivers(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (DriverMatching_WatchFreeDriversClient, error)
+	RateOrder(ctx context.Context, in *RateOrderRequest, opts ...grpc.CallOption) (*RateOrderResponse, error)
+	ReleaseDriver(ctx context.Context, in *ReleaseDriverRequest, opts ...grpc.CallOption) (*ReleaseDriverResponse, error)
+}
+
+type driverMatchingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDriverMatchingClient(cc grpc.ClientConnInterface) DriverMatchingClient {
+	return &driverMatchingClient{cc}
+}
+
+func (c *driverMatchingClient) FindFreeDriver(ctx context.Context, in *FindDriverRequest, opts ...grpc.CallOption) (*FindDriverResponse, error) {
+	out := new(FindDriverResponse)
+	err := c.cc.Invoke(ctx, "/DriverMatching/FindFreeDriver", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverMatchingClient) WatchFreeDrivers(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (DriverMatching_WatchFreeDriversClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DriverMatching_ServiceDesc.Streams[0], "/DriverMatching/WatchFreeDrivers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &driverMatchingWatchFreeDriversClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DriverMatching_WatchFreeDriversClient interface {
+	Recv() (*FreeDriver, error)
+	grpc.ClientStream
+}
+
+type driverMatchingWatchFreeDriversClient struct {
+	grpc.ClientStream
+}
+
+func (x *driverMatchingWatchFreeDriversClient) Recv() (*FreeDriver, error) {
+	m := new(FreeDriver)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *driverMatchingClient) RateOrder(ctx context.Context, in *RateOrderRequest, opts ...grpc.CallOption) (*RateOrderResponse, error) {
+	out := new(RateOrderResponse)
+	err := c.cc.Invoke(ctx, "/DriverMatching/RateOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverMatchingClient) ReleaseDriver(ctx context.Context, in *ReleaseDriverRequest, opts ...grpc.CallOption) (*ReleaseDriverResponse, error) {
+	out := new(ReleaseDriverResponse)
+	err := c.cc.Invoke(ctx, "/DriverMatching/ReleaseDriver", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DriverMatchingServer is the server API for DriverMatching service.
+// All implementations should embed UnimplementedDriverMatchingServer
+// for forward compatibility
+type DriverMatchingServer interface {
+	FindFreeDriver(context.Context, *FindDriverRequest) (*FindDriverResponse, error)
+	WatchFreeDrivers(*WatchRequest, DriverMatching_WatchFreeDriversServer) error
+	RateOrder(context.Context, *RateOrderRequest) (*RateOrderResponse, error)
+	ReleaseDriver(context.Context, *ReleaseDriverRequest) (*ReleaseDriverResponse, error)
+}
+
+// UnimplementedDriverMatchingServer should be embedded to have forward compatible implementations.
+type UnimplementedDriverMatchingServer struct {
+}
+
+func (UnimplementedDriverMatchingServer) FindFreeDriver(context.Context, *FindDriverRequest) (*FindDriverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindFreeDriver not implemented")
+}
+func (UnimplementedDriverMatchingServer) WatchFreeDrivers(*WatchRequest, DriverMatching_WatchFreeDriversServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFreeDrivers not implemented")
+}
+func (UnimplementedDriverMatchingServer) RateOrder(context.Context, *RateOrderRequest) (*RateOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateOrder not implemented")
+}
+func (UnimplementedDriverMatchingServer) ReleaseDriver(context.Context, *ReleaseDriverRequest) (*ReleaseDriverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseDriver not implemented")
+}
+
+// UnsafeDriverMatchingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DriverMatchingServer will
+// result in compilation errors.
+type UnsafeDriverMatchingServer interface {
+	mustEmbedUnimplementedDriverMatchingServer()
+}
+
+func RegisterDriverMatchingServer(s grpc.ServiceRegistrar, srv DriverMatchingServer) {
+	s.RegisterService(&DriverMatching_ServiceDesc, srv)
+}
+
+func _DriverMatching_FindFreeDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverMatchingServer).FindFreeDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DriverMatching/FindFreeDriver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverMatchingServer).FindFreeDriver(ctx, req.(*FindDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverMatching_WatchFreeDrivers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DriverMatchingServer).WatchFreeDrivers(m, &driverMatchingWatchFreeDriversServer{stream})
+}
+
+type DriverMatching_WatchFreeDriversServer interface {
+	Send(*FreeDriver) error
+	grpc.ServerStream
+}
+
+type driverMatchingWatchFreeDriversServer struct {
+	grpc.ServerStream
+}
+
+func (x *driverMatchingWatchFreeDriversServer) Send(m *FreeDriver) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DriverMatching_RateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverMatchingServer).RateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DriverMatching/RateOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverMatchingServer).RateOrder(ctx, req.(*RateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverMatching_ReleaseDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverMatchingServer).ReleaseDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/DriverMatching/ReleaseDriver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverMatchingServer).ReleaseDriver(ctx, req.(*ReleaseDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DriverMatching_ServiceDesc is the grpc.ServiceDesc for DriverMatching service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DriverMatching_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "DriverMatching",
+	HandlerType: (*DriverMatchingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindFreeDriver",
+			Handler:    _DriverMatching_FindFreeDriver_Handler,
+		},
+		{
+			MethodName: "RateOrder",
+			Handler:    _DriverMatching_RateOrder_Handler,
+		},
+		{
+			MethodName: "ReleaseDriver",
+			Handler:    _DriverMatching_ReleaseDriver_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFreeDrivers",
+			Handler:       _DriverMatching_WatchFreeDrivers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "params.proto",
+}
@@ -15,11 +15,14 @@ type Config struct {
 
 	SERVER_HOST string `mapstructure:"SERVER_HOST"`
 
-	SALT string `mapstructure:"SALT"`
+	SALT            string `mapstructure:"SALT"`
+	PASSWORD_HASHER string `mapstructure:"PASSWORD_HASHER"`
 
 	ACCESS_TOKEN_EXP  int    `mapstructure:"ACCESS_TOKEN_EXP"`
 	REFRESH_TOKEN_EXP int    `mapstructure:"REFRESH_TOKEN_EXP"`
 	HS256_SECRET      string `mapstructure:"HS256_SECRET"`
+	JWT_KEYS_DIR      string `mapstructure:"JWT_KEYS_DIR"`
+	JWT_KID           string `mapstructure:"JWT_KID"`
 
 	REDIS_DB_HOST     string `mapstructure:"REDIS_DB_HOST"`
 	REDIS_DB_PASSWORD string `mapstructure:"REDIS_DB_PASSWORD"`
@@ -29,7 +32,45 @@ type Config struct {
 	MONGO_DB_USERNAME string `mapstructure:"MONGO_DB_USERNAME"`
 	MONGO_DB_PASSWORD string `mapstructure:"MONGO_DB_PASSWORD"`
 
-	GRPC_HOST string `mapstructure:"GRPC_HOST"`
+	LOG_BATCH_SIZE     int    `mapstructure:"LOG_BATCH_SIZE"`
+	LOG_FLUSH_INTERVAL int    `mapstructure:"LOG_FLUSH_INTERVAL"`
+	LOG_QUEUE_SIZE     int    `mapstructure:"LOG_QUEUE_SIZE"`
+	LOG_QUEUE_POLICY   string `mapstructure:"LOG_QUEUE_POLICY"`
+	LOG_TTL            int    `mapstructure:"LOG_TTL"`
+
+	GRPC_HOST        string `mapstructure:"GRPC_HOST"`
+	DRIVER_GRPC_HOST string `mapstructure:"DRIVER_GRPC_HOST"`
+	GRPC_TOKEN       string `mapstructure:"GRPC_TOKEN"`
+
+	DRIVER_WAIT_TIME int `mapstructure:"DRIVER_WAIT_TIME"`
+	RATING_TIME      int `mapstructure:"RATING_TIME"`
+
+	OTP_EXP      int    `mapstructure:"OTP_EXP"`
+	OTP_ATTEMPTS int    `mapstructure:"OTP_ATTEMPTS"`
+	SMS_LOG_PATH string `mapstructure:"SMS_LOG_PATH"`
+
+	RESET_TOKEN_EXP int `mapstructure:"RESET_TOKEN_EXP"`
+
+	LOGIN_ATTEMPTS    int `mapstructure:"LOGIN_ATTEMPTS"`
+	LOGIN_IP_ATTEMPTS int `mapstructure:"LOGIN_IP_ATTEMPTS"`
+	LOGIN_WINDOW      int `mapstructure:"LOGIN_WINDOW"`
+	LOGIN_LOCKOUT     int `mapstructure:"LOGIN_LOCKOUT"`
+
+	RATE_LIMIT_BACKEND string `mapstructure:"RATE_LIMIT_BACKEND"`
+	RATE_LIMIT_IP      int    `mapstructure:"RATE_LIMIT_IP"`
+	RATE_LIMIT_AUTH    int    `mapstructure:"RATE_LIMIT_AUTH"`
+	RATE_LIMIT_USERS   int    `mapstructure:"RATE_LIMIT_USERS"`
+	RATE_LIMIT_ADMIN   int    `mapstructure:"RATE_LIMIT_ADMIN"`
+	RATE_LIMIT_GRPC    int    `mapstructure:"RATE_LIMIT_GRPC"`
+
+	SMTP_HOST     string `mapstructure:"SMTP_HOST"`
+	SMTP_USERNAME string `mapstructure:"SMTP_USERNAME"`
+	SMTP_PASSWORD string `mapstructure:"SMTP_PASSWORD"`
+	SMTP_FROM     string `mapstructure:"SMTP_FROM"`
+	MAIL_LOG_PATH string `mapstructure:"MAIL_LOG_PATH"`
+
+	TRACE_EXPORTER      string `mapstructure:"TRACE_EXPORTER"`
+	TRACE_OTLP_ENDPOINT string `mapstructure:"TRACE_OTLP_ENDPOINT"`
 }
 
 func New() (*Config, error) {
@@ -1,18 +1,24 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/RipperAcskt/innotaxi/config"
 	"github.com/RipperAcskt/innotaxi/internal/handler/grpc"
 	handler "github.com/RipperAcskt/innotaxi/internal/handler/restapi"
+	"github.com/RipperAcskt/innotaxi/internal/metrics"
+	"github.com/RipperAcskt/innotaxi/internal/notify"
+	"github.com/RipperAcskt/innotaxi/internal/ratelimit"
 	"github.com/RipperAcskt/innotaxi/internal/repo/mongo"
 	"github.com/RipperAcskt/innotaxi/internal/repo/postgres"
 	"github.com/RipperAcskt/innotaxi/internal/repo/redis"
 	"github.com/RipperAcskt/innotaxi/internal/server"
 	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/RipperAcskt/innotaxi/internal/tracing"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -26,6 +32,21 @@ func Run() error {
 		return fmt.Errorf("config new failed: %w", err)
 	}
 
+	// The services accept only the gRPC calls with the shared token.
+	if cfg.GRPC_TOKEN == "" {
+		return grpc.ErrTokenRequired
+	}
+
+	shutdownTracing, err := tracing.New(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("tracing new failed: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = shutdownTracing(ctx)
+	}()
+
 	postgres, err := postgres.New(cfg)
 	if err != nil {
 		return fmt.Errorf("postgres new failed: %w", err)
@@ -43,6 +64,15 @@ func Run() error {
 	}
 	defer redis.Close()
 
+	err = metrics.RegisterDB(postgres.DB, cfg.POSTGRES_DB_NAME)
+	if err != nil {
+		return fmt.Errorf("register db metrics failed: %w", err)
+	}
+	err = metrics.RegisterRedis(redis)
+	if err != nil {
+		return fmt.Errorf("register redis metrics failed: %w", err)
+	}
+
 	mongo, err := mongo.New(cfg)
 	if err != nil {
 		return fmt.Errorf("mongo new failed: %w", err)
@@ -67,8 +97,29 @@ func Run() error {
 		}
 	}()
 
-	service := service.New(postgres, redis, cfg.SALT, cfg)
-	handler := handler.New(service, cfg, log)
+	drivers, err := grpc.NewClient(log, cfg)
+	if err != nil {
+		return fmt.Errorf("grpc new client failed: %w", err)
+	}
+	defer drivers.Close()
+
+	keys, err := service.LoadKeySet(cfg)
+	if err != nil {
+		return fmt.Errorf("load key set failed: %w", err)
+	}
+
+	var mailer service.Mailer = notify.NewLogMailer(cfg)
+	if cfg.SMTP_HOST != "" {
+		mailer = notify.NewSMTPMailer(cfg)
+	}
+
+	service := service.New(postgres, redis, mongo, drivers, notify.NewLogSms(cfg), mailer, keys, cfg.SALT, cfg)
+	var limiter ratelimit.Limiter = ratelimit.NewMemory()
+	if cfg.RATE_LIMIT_BACKEND == ratelimit.BackendRedis {
+		limiter = redis
+	}
+
+	handler := handler.New(service, limiter, cfg, log)
 	server := &server.Server{
 		Log: log,
 	}
@@ -80,7 +131,11 @@ func Run() error {
 		}
 	}()
 
-	grpcServer := grpc.New(log, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go drivers.WatchFreeDrivers(ctx, service)
+
+	grpcServer := grpc.New(log, keys, limiter, cfg)
 	go func() {
 		if err := grpcServer.Run(); err != nil {
 			log.Error(fmt.Sprintf("grpc server run failed: %v", err))
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authMetadataKey = "authorization"
+	authScheme      = "Bearer "
+)
+
+var ErrTokenRequired = fmt.Errorf("grpc token required")
+
+// Auth returns the interceptor which rejects the calls without the shared
+// GRPC_TOKEN of the services in their authorization metadata, so only the
+// driver service can have tokens issued.
+func Auth(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get(authMetadataKey) {
+			got := strings.TrimPrefix(value, authScheme)
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+				return handler(ctx, req)
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid grpc token")
+	}
+}
+
+// tokenCredentials passes the shared GRPC_TOKEN of the services with every
+// call of the client. The services talk over plain connections inside the
+// cluster, so it doesn't require transport security.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{authMetadataKey: authScheme + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/RipperAcskt/innotaxi/pkg/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const reconnectTimeout = 5 * time.Second
+
+// FreeDriverHandler is told about drivers which became free.
+type FreeDriverHandler interface {
+	DriverReleased(ctx context.Context, driverID, taxiType string) error
+	WakeUpQueues()
+}
+
+type Client struct {
+	conn   *grpc.ClientConn
+	client proto.DriverMatchingClient
+	log    *zap.Logger
+}
+
+func NewClient(log *zap.Logger, cfg *config.Config) (*Client, error) {
+	conn, err := grpc.Dial(cfg.DRIVER_GRPC_HOST,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(tokenCredentials(cfg.GRPC_TOKEN)),
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(), PropagateRequestID()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial failed: %w", err)
+	}
+
+	return &Client{conn, proto.NewDriverMatchingClient(conn), log}, nil
+}
+
+func (c *Client) FindFreeDriver(ctx context.Context, order *model.Order) (string, error) {
+	req := &proto.FindDriverRequest{
+		UserID:   order.UserID,
+		TaxiType: order.TaxiType,
+		From:     order.From,
+		To:       order.To,
+	}
+
+	res, err := c.client.FindFreeDriver(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return "", service.ErrDriverNotFound
+		}
+		return "", fmt.Errorf("find free driver failed: %w", err)
+	}
+
+	return res.DriverID, nil
+}
+
+func (c *Client) RateDriver(ctx context.Context, order *model.Order, rating int) error {
+	req := &proto.RateOrderRequest{
+		DriverID: order.DriverID,
+		UserID:   order.UserID,
+		Rating:   int32(rating),
+	}
+
+	_, err := c.client.RateOrder(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("driver %v: %w", order.DriverID, service.ErrOrderNotFound)
+		}
+		return fmt.Errorf("rate order failed: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) ReleaseDriver(ctx context.Context, order *model.Order) error {
+	req := &proto.ReleaseDriverRequest{
+		DriverID: order.DriverID,
+		UserID:   order.UserID,
+	}
+
+	_, err := c.client.ReleaseDriver(ctx, req)
+	if err != nil {
+		return fmt.Errorf("release driver failed: %w", err)
+	}
+	return nil
+}
+
+// WatchFreeDrivers listens to drivers becoming free until ctx is done and
+// passes them to h. The stream is reopened if it breaks.
+func (c *Client) WatchFreeDrivers(ctx context.Context, h FreeDriverHandler) {
+	for {
+		err := c.watch(ctx, h)
+		if ctx.Err() != nil {
+			return
+		}
+		c.log.Error("watch free drivers failed", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectTimeout):
+		}
+	}
+}
+
+func (c *Client) watch(ctx context.Context, h FreeDriverHandler) error {
+	stream, err := c.client.WatchFreeDrivers(ctx, &proto.WatchRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		return fmt.Errorf("watch free drivers failed: %w", err)
+	}
+
+	h.WakeUpQueues()
+
+	for {
+		driver, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("recv failed: %w", err)
+		}
+
+		err = h.DriverReleased(ctx, driver.DriverID, driver.TaxiType)
+		if err != nil {
+			c.log.Error("driver released failed", zap.Error(err))
+		}
+	}
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "innotaxi.user"
+
+var codeStatus = map[service.Code]codes.Code{
+	service.CodeInvalidArgument:    codes.InvalidArgument,
+	service.CodeValidation:         codes.InvalidArgument,
+	service.CodeUnauthenticated:    codes.Unauthenticated,
+	service.CodePermissionDenied:   codes.PermissionDenied,
+	service.CodeNotFound:           codes.NotFound,
+	service.CodeAlreadyExists:      codes.AlreadyExists,
+	service.CodeFailedPrecondition: codes.FailedPrecondition,
+	service.CodeResourceExhausted:  codes.ResourceExhausted,
+	service.CodeInternal:           codes.Internal,
+}
+
+// Errors returns the interceptor which turns the errors of the calls into
+// statuses. The code of service.Error is sent as the reason of an ErrorInfo
+// detail and its details as the metadata, every other error is logged and
+// sent as ErrInternal.
+func Errors(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		res, err := handler(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		e := service.AsError(err)
+		if e.Code == service.CodeInternal {
+			callLogger(ctx, log).Error(info.FullMethod, zap.Error(err))
+		}
+		return nil, toStatus(e).Err()
+	}
+}
+
+func toStatus(e *service.Error) *status.Status {
+	code, ok := codeStatus[e.Code]
+	if !ok {
+		code = codes.Internal
+	}
+	st := status.New(code, e.Message)
+
+	metadata := make(map[string]string, len(e.Details))
+	for key, value := range e.Details {
+		metadata[key] = fmt.Sprint(value)
+	}
+	withInfo, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   string(e.Code),
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st
+	}
+	return withInfo
+}
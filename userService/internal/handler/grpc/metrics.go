@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics returns the interceptor which counts the calls and observes their
+// latency by method and code. It must go before Errors to see the codes the
+// clients get.
+func Metrics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+
+		code := status.Code(err).String()
+		metrics.GRPCRequests.WithLabelValues(info.FullMethod, code).Inc()
+		metrics.GRPCDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+		return res, err
+	}
+}
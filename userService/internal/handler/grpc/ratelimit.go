@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+
+	"github.com/RipperAcskt/innotaxi/internal/ratelimit"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	defaultIPRateLimit   = 1200
+	defaultGRPCRateLimit = 600
+)
+
+// RateLimit returns the interceptor which limits the unary calls in the
+// buckets of name, keyed by what by returns for the call. The rate limit
+// headers are sent in the header metadata, denied calls fail with
+// service.ErrRateLimited, so it must go after Errors. If the limiter fails the
+// call is let through.
+func RateLimit(limiter ratelimit.Limiter, name string, limit ratelimit.Limit, by func(ctx context.Context, req interface{}) string, log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := name + ":" + by(ctx, req)
+
+		res, err := limiter.Take(ctx, key, limit)
+		if err != nil {
+			callLogger(ctx, log).Error(info.FullMethod, zap.Error(fmt.Errorf("limiter take failed: %w", err)))
+			return handler(ctx, req)
+		}
+
+		md := metadata.MD{}
+		for header, value := range res.Headers() {
+			md.Set(strings.ToLower(header), value)
+		}
+		err = grpc.SetHeader(ctx, md)
+		if err != nil {
+			callLogger(ctx, log).Error(info.FullMethod, zap.Error(fmt.Errorf("set header failed: %w", err)))
+		}
+
+		if !res.Allowed {
+			return nil, service.ErrRateLimited.WithDetails(map[string]interface{}{
+				"retry_after": int(math.Ceil(res.RetryAfter.Seconds())),
+			})
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ByIP keys the calls by the client IP. It's used before Auth, so the calls
+// with a wrong token are counted too.
+func ByIP(ctx context.Context, req interface{}) string {
+	return "ip:" + clientIP(ctx)
+}
+
+// ByCaller keys the calls by the driver or the user of the request, by the
+// client IP if the request has neither. The ids are only trusted after Auth.
+func ByCaller(ctx context.Context, req interface{}) string {
+	if req, ok := req.(interface{ GetDriverID() string }); ok && req.GetDriverID() != "" {
+		return "driver:" + req.GetDriverID()
+	}
+	if req, ok := req.(interface{ GetUserID() uint64 }); ok && req.GetUserID() != 0 {
+		return "user:" + fmt.Sprint(req.GetUserID())
+	}
+	return ByIP(ctx, req)
+}
+
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
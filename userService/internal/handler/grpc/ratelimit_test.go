@@ -0,0 +1,47 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"google.golang.org/grpc/peer"
+
+	handler "github.com/RipperAcskt/innotaxi/internal/handler/grpc"
+	"github.com/RipperAcskt/innotaxi/pkg/proto"
+)
+
+func TestByCaller(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+	})
+
+	test := []struct {
+		name string
+		req  interface{}
+		key  string
+	}{
+		{
+			name: "driver",
+			req:  &proto.Params{DriverID: "5f3c", Type: "driver"},
+			key:  "driver:5f3c",
+		},
+		{
+			name: "user",
+			req:  &proto.Params{UserID: 7},
+			key:  "user:7",
+		},
+		{
+			name: "no caller",
+			req:  &proto.Params{},
+			key:  "ip:10.0.0.1",
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, handler.ByCaller(ctx, tt.req), tt.key)
+		})
+	}
+}
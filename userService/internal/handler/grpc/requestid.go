@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/RipperAcskt/innotaxi/internal/requestid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestID returns the interceptor which puts the request ID of the call
+// into its context, taken from the x-request-id metadata or a new one, and
+// sends it back in the header metadata.
+func RequestID(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ids := md.Get(requestid.MetadataKey); len(ids) > 0 {
+				id = ids[0]
+			}
+		}
+		id = requestid.Get(id)
+
+		err := grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+		if err != nil {
+			log.Error(info.FullMethod, zap.String("request_id", id), zap.Error(err))
+		}
+		return handler(requestid.NewContext(ctx, id), req)
+	}
+}
+
+// PropagateRequestID returns the client interceptor which passes the request
+// ID of the context of a call in the x-request-id metadata.
+func PropagateRequestID() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := requestid.FromContext(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// callLogger returns log with the request ID of ctx.
+func callLogger(ctx context.Context, log *zap.Logger) *zap.Logger {
+	return log.With(zap.String("request_id", requestid.FromContext(ctx)))
+}
@@ -6,8 +6,10 @@ import (
 	"net"
 
 	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/ratelimit"
 	"github.com/RipperAcskt/innotaxi/internal/service"
 	"github.com/RipperAcskt/innotaxi/pkg/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -16,11 +18,13 @@ type Server struct {
 	listener   net.Listener
 	grpcServer *grpc.Server
 	log        *zap.Logger
+	keys       *service.KeySet
+	limiter    ratelimit.Limiter
 	cfg        *config.Config
 }
 
-func New(log *zap.Logger, cfg *config.Config) *Server {
-	return &Server{nil, nil, log, cfg}
+func New(log *zap.Logger, keys *service.KeySet, limiter ratelimit.Limiter, cfg *config.Config) *Server {
+	return &Server{nil, nil, log, keys, limiter, cfg}
 }
 
 func (s *Server) Run() error {
@@ -30,7 +34,26 @@ func (s *Server) Run() error {
 		return fmt.Errorf("listen failed: %w", err)
 	}
 
-	opts := []grpc.ServerOption{}
+	ipLimit := s.cfg.RATE_LIMIT_IP
+	if ipLimit == 0 {
+		ipLimit = defaultIPRateLimit
+	}
+	limit := s.cfg.RATE_LIMIT_GRPC
+	if limit == 0 {
+		limit = defaultGRPCRateLimit
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			otelgrpc.UnaryServerInterceptor(),
+			RequestID(s.log),
+			Metrics(),
+			Errors(s.log),
+			RateLimit(s.limiter, "grpc-ip", ratelimit.PerMinute(ipLimit), ByIP, s.log),
+			Auth(s.cfg.GRPC_TOKEN),
+			RateLimit(s.limiter, "grpc", ratelimit.PerMinute(limit), ByCaller, s.log),
+		),
+	}
 	grpcServer := grpc.NewServer(opts...)
 
 	s.listener = listener
@@ -45,11 +68,18 @@ func (s *Server) Run() error {
 	return nil
 }
 
+// GetJWT issues the access token of a driver who signed in to the driver
+// service. Drivers have no session here, so they get no refresh token and
+// sign in again when the access token expires.
 func (s *Server) GetJWT(c context.Context, params *proto.Params) (*proto.Response, error) {
+	if params.Type != service.Driver {
+		return nil, service.NewError(service.CodeInvalidArgument, service.ErrUnknownType.Message)
+	}
+
 	tokenParams := service.TokenParams{
 		ID:                params.DriverID,
 		Type:              params.Type,
-		HS256_SECRET:      s.cfg.HS256_SECRET,
+		Keys:              s.keys,
 		ACCESS_TOKEN_EXP:  s.cfg.ACCESS_TOKEN_EXP,
 		REFRESH_TOKEN_EXP: s.cfg.REFRESH_TOKEN_EXP,
 	}
@@ -60,8 +90,7 @@ func (s *Server) GetJWT(c context.Context, params *proto.Params) (*proto.Respons
 	}
 
 	response := &proto.Response{
-		AccessToken:  token.Access,
-		RefreshToken: token.RT,
+		AccessToken: token.Access,
 	}
 	return response, nil
 }
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"go.uber.org/zap"
+)
+
+const (
+	formatNDJSON = "ndjson"
+	mimeNDJSON   = "application/x-ndjson"
+)
+
+// @Summary list users
+// @Tags admin
+// @Param status query string false "pending, created or deleted"
+// @Param name query string false "name prefix"
+// @Param phone query string false "phone number prefix"
+// @Param email query string false "email prefix"
+// @Param min_rating query number false "min rating"
+// @Param max_rating query number false "max rating"
+// @Param sort query string false "id, name or rating, prefixed with - for descending order"
+// @Param limit query int false "page size, 20 by default"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Produce json
+// @Success 200 {object} service.UsersPage
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /admin/users [GET]
+// @Security Bearer
+func (h *Handler) ListUsers(c *gin.Context) {
+	var filter service.UsersFilter
+
+	if !bind(c, &filter, binding.Query) {
+		return
+	}
+
+	page, err := h.s.ListUsers(c.Request.Context(), &filter)
+	if err != nil {
+		abort(c, fmt.Errorf("list users failed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary restore deleted user
+// @Tags admin
+// @Param id path int true "user's id"
+// @Success 200
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /admin/users/{id}/restore [POST]
+// @Security Bearer
+func (h *Handler) RestoreUser(c *gin.Context) {
+	err := h.s.RestoreUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abort(c, fmt.Errorf("restore user failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary list logs
+// @Tags admin
+// @Param level query string false "debug, info, warn, error, dpanic, panic or fatal"
+// @Param from query string false "RFC 3339 time of the oldest entry"
+// @Param to query string false "RFC 3339 time the entries are older than"
+// @Param request_id query string false "request ID or uuid of the entries"
+// @Param method query string false "HTTP method"
+// @Param url query string false "URL path prefix"
+// @Param limit query int false "page size, 50 by default"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param format query string false "ndjson to export every entry as NDJSON"
+// @Produce json,application/x-ndjson
+// @Success 200 {object} service.LogsPage
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /admin/logs [GET]
+// @Security Bearer
+func (h *Handler) ListLogs(c *gin.Context) {
+	var filter service.LogsFilter
+
+	if !bind(c, &filter, binding.Query) {
+		return
+	}
+
+	if c.Query("format") == formatNDJSON || c.NegotiateFormat(gin.MIMEJSON, mimeNDJSON) == mimeNDJSON {
+		h.exportLogs(c, &filter)
+		return
+	}
+
+	page, err := h.s.ListLogs(c.Request.Context(), &filter)
+	if err != nil {
+		abort(c, fmt.Errorf("list logs failed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// exportLogs streams every entry of the filter, one JSON object per line.
+// Once the first entry is sent the status can't change, so a failure after
+// it only ends the stream and is logged.
+func (h *Handler) exportLogs(c *gin.Context, filter *service.LogsFilter) {
+	started := false
+	enc := json.NewEncoder(c.Writer)
+
+	err := h.s.ExportLogs(c.Request.Context(), filter, func(log *model.Log) error {
+		if !started {
+			c.Header("Content-Type", mimeNDJSON)
+			c.Status(http.StatusOK)
+			started = true
+		}
+
+		err := enc.Encode(log)
+		if err != nil {
+			return fmt.Errorf("encode failed: %w", err)
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if started {
+			getLogger(c).Error("/admin/logs", zap.Error(fmt.Errorf("export logs failed: %w", err)))
+			return
+		}
+		abort(c, fmt.Errorf("export logs failed: %w", err))
+		return
+	}
+
+	if !started {
+		c.Header("Content-Type", mimeNDJSON)
+		c.Status(http.StatusOK)
+	}
+}
@@ -3,14 +3,17 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt"
+	"github.com/gin-gonic/gin/binding"
 	"go.uber.org/zap"
 
+	"github.com/RipperAcskt/innotaxi/internal/metrics"
 	"github.com/RipperAcskt/innotaxi/internal/service"
 )
 
@@ -19,76 +22,98 @@ import (
 // @Param user body service.UserSingUp true "account info"
 // @Accept json
 // @Success 200
-// @Failure 400 {object} error "error: err"
-// @Failure 500 {object} error "error: err"
+// @Failure 400 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /users/auth/sing-up [POST]
 func (h *Handler) SingUp(c *gin.Context) {
-	logger := getLogger(c)
-
 	var user service.UserSingUp
 
-	if err := c.BindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bind(c, &user, binding.JSON) {
 		return
 	}
 
 	err := h.s.SingUp(c.Request.Context(), user)
 	if err != nil {
-		if errors.Is(err, service.ErrUserAlreadyExists) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-			return
-		}
-
-		logger.Error("/users/auth/sing-up", zap.Error(fmt.Errorf("service sing up failed: %w", err)))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		abort(c, fmt.Errorf("service sing up failed: %w", err))
 		return
 	}
+	metrics.SignUps.Inc()
 
 	c.Status(http.StatusCreated)
 }
 
+// @Summary verify phone number with the code sent on sign up
+// @Tags auth
+// @Param input body service.PhoneVerify true "phone number and code"
+// @Accept json
+// @Success 200
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 429 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/auth/verify-phone [POST]
+func (h *Handler) VerifyPhone(c *gin.Context) {
+	var verify service.PhoneVerify
+
+	if !bind(c, &verify, binding.JSON) {
+		return
+	}
+
+	err := h.s.VerifyPhone(c.Request.Context(), verify)
+	if err != nil {
+		abort(c, fmt.Errorf("service verify phone failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
 // @Summary user authentication
 // @Tags auth
 // @Param input body service.UserSingIn true "phone number and password"
 // @Accept json
 // @Produce json
 // @Success 200 {object} string "access_token: token"
-// @Failure 403 {object} error "error: err"
-// @Failure 500 {object} error "error: err"
+// @Failure 403 {object} Problem
+// @Failure 429 {object} Problem
+// @Header 403,429 {integer} Retry-After "seconds until the next attempt is allowed"
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /users/auth/sing-in [POST]
 func (h *Handler) SingIn(c *gin.Context) {
 	logger := getLogger(c)
 
 	var user service.UserSingIn
 
-	if err := c.BindJSON(&user); err != nil {
-
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bind(c, &user, binding.JSON) {
 		return
 	}
+	user.IP = c.ClientIP()
+	user.UserAgent = c.Request.UserAgent()
+
 	token, err := h.s.SingIn(c.Request.Context(), user)
 	if err != nil {
-		if errors.Is(err, service.ErrUserDoesNotExists) || errors.Is(err, service.ErrIncorrectPassword) {
+		if errors.Is(err, service.ErrIncorrectPassword) {
+			metrics.FailedLogins.WithLabelValues(metrics.ReasonCredentials).Inc()
+		} else if errors.Is(err, service.ErrSignInLocked) {
+			metrics.FailedLogins.WithLabelValues(metrics.ReasonLocked).Inc()
+		}
 
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": err.Error(),
-			})
-			return
+		var locked *service.LockedError
+		if errors.As(err, &locked) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.Retry.Seconds()))))
+			if locked.Lockout {
+				logger.Warn("/users/auth/sing-in", zap.Error(fmt.Errorf("%v locked for %v: %w", locked.Key, locked.Retry, service.ErrSignInLocked)))
+			}
 		}
-		logger.Error("/users/auth/sing-in", zap.Error(fmt.Errorf("service sing in failed: %w", err)))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		abort(c, fmt.Errorf("service sing in failed: %w", err))
 		return
 	}
+	metrics.SignIns.Inc()
 
 	exp := int((time.Duration(h.Cfg.REFRESH_TOKEN_EXP) * time.Hour * 24).Seconds())
 	c.SetCookie("refresh_token", token.RT, exp, "/users/auth", "", false, true)
@@ -97,48 +122,45 @@ func (h *Handler) SingIn(c *gin.Context) {
 	})
 }
 
-func (h *Handler) VerifyToken() gin.HandlerFunc {
+// VerifyToken lets the request through if the access token's role has every
+// scope for the user of the :id path parameter.
+func (h *Handler) VerifyToken(scopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		logger := getLogger(c)
-
 		token := strings.Split(c.GetHeader("Authorization"), " ")
 		if len(token) < 2 {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": fmt.Errorf("access token required").Error(),
-			})
+			abort(c, service.ErrTokenRequired)
 			return
 		}
 		accessToken := token[1]
 
-		id, err := service.Verify(accessToken, h.Cfg)
+		claims, err := h.s.VerifyAccess(c.Request.Context(), accessToken)
 		if err != nil {
-			if errors.Is(err, service.ErrTokenExpired) {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-					"error": err.Error(),
-				})
-				return
-			}
-			if strings.Contains(err.Error(), jwt.ErrSignatureInvalid.Error()) {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-					"error": fmt.Errorf("wrong signature").Error(),
-				})
-				return
-			}
-
-			logger.Error("service verify failed", zap.Error(err))
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-				"error": fmt.Errorf("verify failed: %w", err).Error(),
-			})
+			abort(c, fmt.Errorf("service verify failed: %w", err))
 			return
 		}
 
-		if !h.s.CheckToken(accessToken) {
-			c.AbortWithStatus(http.StatusForbidden)
+		if !h.s.CheckToken(c.Request.Context(), accessToken) {
+			abort(c, service.ErrSessionRevoked)
 			return
 		}
-		c.Set("id", fmt.Sprint(id))
-		if c.Param("id") != "" && fmt.Sprint(id) != c.Param("id") {
-			c.AbortWithStatus(http.StatusForbidden)
+		c.Set("id", fmt.Sprint(claims.UserID))
+		setLoggerUser(c, fmt.Sprint(claims.UserID))
+		c.Set("session", claims.SessionID)
+		c.Set("role", claims.Role)
+
+		// Drivers reach the resources of a user only while they drive them.
+		var passenger bool
+		if claims.Role == service.Driver && c.Param("id") != "" {
+			passenger, err = h.s.IsPassenger(c.Request.Context(), fmt.Sprint(claims.UserID), c.Param("id"))
+			if err != nil {
+				abort(c, fmt.Errorf("is passenger failed: %w", err))
+				return
+			}
+		}
+
+		err = service.Authorize(claims, c.Param("id"), passenger, scopes...)
+		if err != nil {
+			abort(c, err)
 			return
 		}
 
@@ -151,9 +173,8 @@ func (h *Handler) VerifyToken() gin.HandlerFunc {
 // @Tags auth
 // @Produce json
 // @Success 200 {object} string "accept token: token"
-// @Failure 401 {object} error  "error: err"
-// @Failure 403 {object} error  "error: err"
-// @Failure 500 {object} error  "error: err"
+// @Failure 401 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /users/auth/refresh [GET]
 func (h *Handler) Refresh(c *gin.Context) {
 	logger := getLogger(c)
@@ -161,63 +182,20 @@ func (h *Handler) Refresh(c *gin.Context) {
 	refresh, err := c.Cookie("refresh_token")
 	if err != nil {
 		if err == http.ErrNoCookie {
-
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": fmt.Errorf("bad refresh token").Error(),
-			})
-			return
-		}
-		logger.Error("/users/auth/refresh", zap.Error(fmt.Errorf("get cookie failed: %w", err)))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
-		return
-	}
-
-	id, err := service.Verify(refresh, h.Cfg)
-	if err != nil {
-		if errors.Is(err, service.ErrTokenExpired) {
-
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": err.Error(),
-			})
-			return
-		}
-		if strings.Contains(err.Error(), jwt.ErrSignatureInvalid.Error()) {
-
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": fmt.Errorf("wrong signature").Error(),
-			})
+			abort(c, service.ErrBadRT)
 			return
 		}
-
-		logger.Error("/users/auth/refresh", zap.Error(fmt.Errorf("verify failed: %w", err)))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Errorf("verify rt failed: %w", err).Error(),
-		})
+		abort(c, fmt.Errorf("get cookie failed: %w", err))
 		return
 	}
 
-	params := service.TokenParams{
-		ID:                id,
-		Type:              service.User,
-		HS256_SECRET:      h.Cfg.HS256_SECRET,
-		ACCESS_TOKEN_EXP:  h.Cfg.ACCESS_TOKEN_EXP,
-		REFRESH_TOKEN_EXP: h.Cfg.REFRESH_TOKEN_EXP,
-	}
-
-	token, err := service.NewToken(params)
+	token, err := h.s.Refresh(c.Request.Context(), refresh)
 	if err != nil {
-		if errors.Is(err, service.ErrUnknownType) {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+		if errors.Is(err, service.ErrRTReused) {
+			logger.Warn("/users/auth/refresh", zap.Error(err))
+			c.SetCookie("refresh_token", "", -1, "/users/auth", "", false, true)
 		}
-
-		logger.Error("/users/auth/refresh", zap.Error(fmt.Errorf("new token failed: %w", err)))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		abort(c, fmt.Errorf("refresh failed: %w", err))
 		return
 	}
 
@@ -232,42 +210,47 @@ func (h *Handler) Refresh(c *gin.Context) {
 // @Tags auth
 // @Accept json
 // @Success 200
-// @Failure 401 {object} error  "error: err"
-// @Failure 403 {object} error  "error: err"
-// @Failure 500 {object} error  "error: err"
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /users/auth/logout [GET]
 // @Security Bearer
 func (h *Handler) Logout(c *gin.Context) {
-	logger := getLogger(c)
-
 	id, ok := c.Get("id")
 	if !ok {
-
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"error": "can't get id",
-		})
+		abort(c, fmt.Errorf("can't get id"))
 		return
 	}
 
 	exp := time.Duration(h.Cfg.ACCESS_TOKEN_EXP) * time.Minute
 	token := strings.Split(c.GetHeader("Authorization"), " ")
 	if len(token) < 2 {
-
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-			"error": fmt.Errorf("access token required").Error(),
-		})
+		abort(c, service.ErrTokenRequired)
 		return
 	}
 	accessToken := token[1]
 
-	err := h.s.Logout(id.(string), accessToken, exp)
+	err := h.s.Logout(c.Request.Context(), id.(string), accessToken, exp)
 	if err != nil {
-		logger.Error("/users/auth/logout", zap.Error(fmt.Errorf("logout failed: %w", err)))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		abort(c, fmt.Errorf("logout failed: %w", err))
+		return
+	}
+
+	err = h.s.RevokeSession(c.Request.Context(), id.(string), c.GetString("session"))
+	if err != nil && !errors.Is(err, service.ErrSessionNotFound) {
+		abort(c, fmt.Errorf("revoke session failed: %w", err))
 		return
 	}
 	c.SetCookie("refresh_token", "", time.Now().Second(), "/users/auth", "", false, true)
+	metrics.Logouts.Inc()
 	c.Status(http.StatusOK)
 }
+
+// @Summary public keys to verify tokens
+// @Tags auth
+// @Produce json
+// @Success 200 {object} service.JWKS
+// @Router /.well-known/jwks.json [GET]
+func (h *Handler) JWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, h.s.JWKS())
+}
@@ -2,42 +2,78 @@ package handler
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.uber.org/zap"
 
 	"github.com/RipperAcskt/innotaxi/config"
 	_ "github.com/RipperAcskt/innotaxi/docs"
+	"github.com/RipperAcskt/innotaxi/internal/ratelimit"
 	"github.com/RipperAcskt/innotaxi/internal/service"
 )
 
 type Handler struct {
-	s   *service.Service
-	Cfg *config.Config
-	log *zap.Logger
+	s       *service.Service
+	limiter ratelimit.Limiter
+	Cfg     *config.Config
+	log     *zap.Logger
 }
 
-func New(s *service.Service, cfg *config.Config, log *zap.Logger) *Handler {
-	return &Handler{s, cfg, log}
+func New(s *service.Service, limiter ratelimit.Limiter, cfg *config.Config, log *zap.Logger) *Handler {
+	registerValidations()
+	return &Handler{s, limiter, cfg, log}
 }
 
 func (h *Handler) InitRouters() *gin.Engine {
 	router := gin.New()
+	router.Use(h.Trace(), h.Metrics())
 
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/.well-known/jwks.json", h.JWKS)
 
 	users := router.Group("/users")
 	users.Use(h.Log())
 
 	auth := users.Group("/auth")
+	auth.Use(h.RateLimit("auth", perMinute(h.Cfg.RATE_LIMIT_AUTH, defaultAuthRateLimit)))
 	auth.POST("sing-up", h.SingUp)
+	auth.POST("verify-phone", h.VerifyPhone)
 	auth.POST("sing-in", h.SingIn)
 	auth.GET("refresh", h.Refresh)
 	auth.GET("logout", h.VerifyToken(), h.Logout)
+	auth.POST("password/forgot", h.ForgotPassword)
+	auth.POST("password/reset", h.ResetPassword)
 
-	users.GET("/profile/:id", h.VerifyToken(), h.GetProfile)
-	users.PUT("/profile/:id", h.VerifyToken(), h.UpdateProfile)
-	users.DELETE("/:id", h.VerifyToken(), h.DeleteUser)
+	// ipLimit goes before VerifyToken to count requests with bad tokens by
+	// the client IP, the limits after it count the requests of every user.
+	ipLimit := h.RateLimit("ip", perMinute(h.Cfg.RATE_LIMIT_IP, defaultIPRateLimit))
+	limit := h.RateLimit("users", perMinute(h.Cfg.RATE_LIMIT_USERS, defaultUsersRateLimit))
+
+	users.GET("/profile/:id", ipLimit, h.VerifyToken(service.ScopeProfileRead), limit, h.GetProfile)
+	users.PUT("/profile/:id", ipLimit, h.VerifyToken(service.ScopeProfileWrite), limit, h.UpdateProfile)
+	users.POST("/profile/:id/email/confirm", ipLimit, h.VerifyToken(service.ScopeProfileWrite), limit, h.ConfirmEmail)
+	users.POST("/profile/:id/phone/confirm", ipLimit, h.VerifyToken(service.ScopeProfileWrite), limit, h.ConfirmPhone)
+	users.DELETE("/:id", ipLimit, h.VerifyToken(service.ScopeProfileDelete), limit, h.DeleteUser)
+	users.PUT("/:id/password", ipLimit, h.VerifyToken(service.ScopePassword), limit, h.ChangePassword)
+
+	users.GET("/:id/sessions", ipLimit, h.VerifyToken(service.ScopeSessions), limit, h.GetSessions)
+	users.DELETE("/:id/sessions", ipLimit, h.VerifyToken(service.ScopeSessions), limit, h.RevokeSessions)
+	users.DELETE("/:id/sessions/:session_id", ipLimit, h.VerifyToken(service.ScopeSessions), limit, h.RevokeSession)
+
+	users.POST("/orders", ipLimit, h.VerifyToken(service.ScopeOrdersWrite), limit, h.CreateOrder)
+	users.GET("/:id/orders", ipLimit, h.VerifyToken(service.ScopeOrdersRead), limit, h.GetOrders)
+	users.POST("/:id/orders/last/rating", ipLimit, h.VerifyToken(service.ScopeOrdersWrite), limit, h.RateLastOrder)
+
+	admin := router.Group("/admin")
+	admin.Use(h.Log())
+
+	adminLimit := h.RateLimit("admin", perMinute(h.Cfg.RATE_LIMIT_ADMIN, defaultAdminRateLimit))
+
+	admin.GET("/users", ipLimit, h.VerifyToken(service.ScopeUsersList), adminLimit, h.ListUsers)
+	admin.POST("/users/:id/restore", ipLimit, h.VerifyToken(service.ScopeUsersRestore), adminLimit, h.RestoreUser)
+	admin.GET("/logs", ipLimit, h.VerifyToken(service.ScopeLogsRead), adminLimit, h.ListLogs)
 
 	return router
 }
@@ -4,23 +4,57 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+
+	"github.com/RipperAcskt/innotaxi/internal/requestid"
 )
 
+// Log builds the logger of the request with its request ID, taken from the
+// X-Request-ID header or a new one, and logs the request once it's served.
+// The ID is sent back in the header and put into the context of the request
+// so the calls it makes carry it too. The trace ID is logged if the request
+// is traced.
 func (h *Handler) Log() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		h.log = h.log.WithOptions(zap.Fields(zap.String("url", c.Request.URL.Path), zap.String("method", c.Request.Method), zap.Any("uuid", uuid.New()), zap.String("request time", time.Now().String())))
-		c.Set("logger", *h.log)
+
+		id := requestid.Get(c.GetHeader(requestid.Header))
+		c.Header(requestid.Header, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+
+		fields := []zap.Field{
+			zap.String("request_id", id),
+			zap.String("method", c.Request.Method),
+			zap.String("url", c.Request.URL.Path),
+			zap.String("route", c.FullPath()),
+		}
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			fields = append(fields, zap.String("trace_id", span.TraceID().String()))
+		}
+		c.Set("logger", h.log.With(fields...))
 		c.Next()
 
-		h.log.Info("request", zap.String("time", time.Since(start).String()))
+		getLogger(c).Info("request",
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+		)
 	}
 }
 
+// setLoggerUser adds the id of the signed in user to the logger of the
+// request.
+func setLoggerUser(c *gin.Context, id string) {
+	c.Set("logger", getLogger(c).With(zap.String("user_id", id)))
+}
+
+// getLogger returns the logger of the request, a no-op logger if the route
+// doesn't go through Log.
 func getLogger(c *gin.Context) *zap.Logger {
 	tmp, _ := c.Get("logger")
-	logger := tmp.(zap.Logger)
-	return &logger
+	logger, ok := tmp.(*zap.Logger)
+	if !ok {
+		return zap.NewNop()
+	}
+	return logger
 }
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// @Summary order taxi
+// @Tags order
+// @Param input body service.OrderCreate true "taxi type, from and to"
+// @Accept json
+// @Produce json
+// @Success 201 {object} model.Order
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 429 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/orders [POST]
+// @Security Bearer
+func (h *Handler) CreateOrder(c *gin.Context) {
+	id, ok := c.Get("id")
+	if !ok {
+		abort(c, fmt.Errorf("can't get id"))
+		return
+	}
+
+	var order service.OrderCreate
+
+	if !bind(c, &order, binding.JSON) {
+		return
+	}
+
+	res, err := h.s.CreateOrder(c.Request.Context(), id.(string), order)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			c.Abort()
+			return
+		}
+		abort(c, fmt.Errorf("create order failed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// @Summary get user's trips
+// @Tags order
+// @Param id path int true "user's id"
+// @Produce json
+// @Success 200 {array} model.Order
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/{id}/orders [GET]
+// @Security Bearer
+func (h *Handler) GetOrders(c *gin.Context) {
+	orders, err := h.s.GetOrders(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abort(c, fmt.Errorf("get orders failed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// @Summary rate last trip
+// @Tags order
+// @Param id path int true "user's id"
+// @Param input body service.OrderRating true "rating from 1 to 5"
+// @Accept json
+// @Success 200
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/{id}/orders/last/rating [POST]
+// @Security Bearer
+func (h *Handler) RateLastOrder(c *gin.Context) {
+	var rating service.OrderRating
+
+	if !bind(c, &rating, binding.JSON) {
+		return
+	}
+
+	err := h.s.RateLastOrder(c.Request.Context(), c.Param("id"), rating)
+	if err != nil {
+		abort(c, fmt.Errorf("rate last order failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
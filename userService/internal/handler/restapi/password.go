@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// @Summary send password reset token
+// @Tags auth
+// @Param input body service.PasswordForgot true "phone number or email"
+// @Accept json
+// @Success 200
+// @Failure 400 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/auth/password/forgot [POST]
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	var forgot service.PasswordForgot
+
+	if !bind(c, &forgot, binding.JSON) {
+		return
+	}
+
+	err := h.s.ForgotPassword(c.Request.Context(), forgot)
+	if err != nil {
+		abort(c, fmt.Errorf("service forgot password failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary reset password with the token
+// @Tags auth
+// @Param input body service.PasswordReset true "reset token and new password"
+// @Accept json
+// @Success 200
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 429 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/auth/password/reset [POST]
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var reset service.PasswordReset
+
+	if !bind(c, &reset, binding.JSON) {
+		return
+	}
+
+	err := h.s.ResetPassword(c.Request.Context(), reset)
+	if err != nil {
+		abort(c, fmt.Errorf("service reset password failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary change password
+// @Tags user
+// @Param input body service.PasswordChange true "old and new password"
+// @Param id path int true "user's id"
+// @Accept json
+// @Success 200
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/{id}/password [PUT]
+// @Security Bearer
+func (h *Handler) ChangePassword(c *gin.Context) {
+	var change service.PasswordChange
+
+	if !bind(c, &change, binding.JSON) {
+		return
+	}
+
+	err := h.s.ChangePassword(c.Request.Context(), c.Param("id"), change)
+	if err != nil {
+		abort(c, fmt.Errorf("change password failed: %w", err))
+		return
+	}
+
+	c.SetCookie("refresh_token", "", -1, "/users/auth", "", false, true)
+	c.Status(http.StatusOK)
+}
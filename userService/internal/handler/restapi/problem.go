@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+)
+
+// Problem is an error response in the RFC 7807 problem details format, with
+// the code and details of service.Error as extension members.
+type Problem struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail"`
+	Instance string                 `json:"instance"`
+	Code     service.Code           `json:"code"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+var codeStatus = map[service.Code]int{
+	service.CodeInvalidArgument:    http.StatusBadRequest,
+	service.CodeValidation:         http.StatusUnprocessableEntity,
+	service.CodeUnauthenticated:    http.StatusUnauthorized,
+	service.CodePermissionDenied:   http.StatusForbidden,
+	service.CodeNotFound:           http.StatusNotFound,
+	service.CodeAlreadyExists:      http.StatusConflict,
+	service.CodeFailedPrecondition: http.StatusBadRequest,
+	service.CodeResourceExhausted:  http.StatusTooManyRequests,
+	service.CodeInternal:           http.StatusInternalServerError,
+}
+
+// abort answers the request with the problem of err. Errors without a
+// service.Error in the chain are logged and answered with ErrInternal, so
+// their messages never reach the client.
+func abort(c *gin.Context, err error) {
+	e := service.AsError(err)
+	if e.Code == service.CodeInternal {
+		getLogger(c).Error(c.FullPath(), zap.Error(err))
+	}
+
+	status, ok := codeStatus[e.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	c.Header("Content-Type", "application/problem+json")
+	c.AbortWithStatusJSON(status, Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   e.Message,
+		Instance: c.Request.URL.Path,
+		Code:     e.Code,
+		Details:  e.Details,
+	})
+}
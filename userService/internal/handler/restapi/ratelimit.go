@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/RipperAcskt/innotaxi/internal/ratelimit"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+)
+
+const (
+	defaultIPRateLimit    = 1200
+	defaultAuthRateLimit  = 30
+	defaultUsersRateLimit = 120
+	defaultAdminRateLimit = 300
+)
+
+// perMinute returns the limit of n requests per minute, of def if n isn't set.
+func perMinute(n, def int) ratelimit.Limit {
+	if n == 0 {
+		n = def
+	}
+	return ratelimit.PerMinute(n)
+}
+
+// RateLimit limits the requests of the group of routes name. After VerifyToken
+// the requests are counted by the id it sets, before it by the client IP. If
+// the limiter fails the request is let through.
+func (h *Handler) RateLimit(name string, limit ratelimit.Limit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger := getLogger(c)
+
+		key := name + ":ip:" + c.ClientIP()
+		if id, ok := c.Get("id"); ok {
+			key = name + ":user:" + fmt.Sprint(id)
+		}
+
+		res, err := h.limiter.Take(c.Request.Context(), key, limit)
+		if err != nil {
+			logger.Error("rate limit", zap.Error(fmt.Errorf("limiter take failed: %w", err)))
+			c.Next()
+			return
+		}
+
+		for header, value := range res.Headers() {
+			c.Header(header, value)
+		}
+		if !res.Allowed {
+			abort(c, service.ErrRateLimited.WithDetails(map[string]interface{}{
+				"retry_after": int(math.Ceil(res.RetryAfter.Seconds())),
+			}))
+			return
+		}
+		c.Next()
+	}
+}
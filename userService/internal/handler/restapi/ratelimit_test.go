@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	handler "github.com/RipperAcskt/innotaxi/internal/handler/restapi"
+	"github.com/RipperAcskt/innotaxi/internal/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handler.New(nil, ratelimit.NewMemory(), &config.Config{}, zap.NewNop())
+
+	// The id is set the way VerifyToken sets it, every request comes from the
+	// same IP.
+	r := gin.New()
+	r.GET("/users/:id",
+		h.RateLimit("ip", ratelimit.PerMinute(10)),
+		func(c *gin.Context) {
+			c.Set("id", c.Param("id"))
+		},
+		h.RateLimit("users", ratelimit.PerMinute(1)),
+		func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		},
+	)
+
+	test := []struct {
+		name      string
+		path      string
+		code      int
+		remaining string
+	}{
+		{
+			name:      "first request of user",
+			path:      "/users/1",
+			code:      http.StatusOK,
+			remaining: "0",
+		},
+		{
+			name:      "user is limited",
+			path:      "/users/1",
+			code:      http.StatusTooManyRequests,
+			remaining: "0",
+		},
+		{
+			name:      "other user from the same ip",
+			path:      "/users/2",
+			code:      http.StatusOK,
+			remaining: "0",
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, tt.code)
+			assert.Equal(t, w.Header().Get("RateLimit-Remaining"), tt.remaining)
+		})
+	}
+}
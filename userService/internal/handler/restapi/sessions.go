@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary get user's sessions
+// @Tags session
+// @Param id path int true "user's id"
+// @Produce json
+// @Success 200 {array} model.Session
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/{id}/sessions [GET]
+// @Security Bearer
+func (h *Handler) GetSessions(c *gin.Context) {
+	sessions, err := h.s.GetSessions(c.Request.Context(), c.Param("id"), c.GetString("session"))
+	if err != nil {
+		abort(c, fmt.Errorf("get sessions failed: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// @Summary sign out on one device
+// @Tags session
+// @Param id path int true "user's id"
+// @Param session_id path string true "session's id"
+// @Success 200
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/{id}/sessions/{session_id} [DELETE]
+// @Security Bearer
+func (h *Handler) RevokeSession(c *gin.Context) {
+	err := h.s.RevokeSession(c.Request.Context(), c.Param("id"), c.Param("session_id"))
+	if err != nil {
+		abort(c, fmt.Errorf("revoke session failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary sign out on every device
+// @Tags session
+// @Param id path int true "user's id"
+// @Success 200
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/{id}/sessions [DELETE]
+// @Security Bearer
+func (h *Handler) RevokeSessions(c *gin.Context) {
+	err := h.s.RevokeSessions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abort(c, fmt.Errorf("revoke sessions failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -1,14 +1,13 @@
 package handler
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/RipperAcskt/innotaxi/internal/model"
 	"github.com/RipperAcskt/innotaxi/internal/service"
 	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
+	"github.com/gin-gonic/gin/binding"
 )
 
 // @Summary get user profile
@@ -16,26 +15,16 @@ import (
 // @Param id path int true "user's id"
 // @Produce json
 // @Success 200 {object} model.User
-// @Failure 401 {object} error "error: err"
-// @Failure 403 {object} error "error: err"
-// @Failure 500 {object} error "error: err"
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /users/profile/{id} [GET]
 // @Security Bearer
 func (h *Handler) GetProfile(c *gin.Context) {
-	logger := getLogger(c)
-
 	user, err := h.s.GetProfile(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		if errors.Is(err, service.ErrUserDoesNotExists) {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-			return
-		}
-		logger.Error("/users/profile/{id}", zap.Error(fmt.Errorf("get profile failed: %w", err)))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Errorf("get profile failed: %w", err).Error(),
-		})
+		abort(c, fmt.Errorf("get profile failed: %w", err))
 		return
 	}
 
@@ -43,44 +32,92 @@ func (h *Handler) GetProfile(c *gin.Context) {
 }
 
 // @Summary update user profile
+// @Description New email and phone number are stored after they are confirmed with the code sent to them.
 // @Tags user
 // @Param input body model.User false "rows to update"
 // @Param id path int true "user's id"
 // @Accept json
 // @Produce json
-// @Success 200 {object} model.User
-// @Failure 401 {object} error "error: err"
-// @Failure 403 {object} error "error: err"
-// @Failure 500 {object} error "error: err"
+// @Success 200 {object} string "pending: fields waiting for confirmation"
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /users/profile/{id} [PUT]
 // @Security Bearer
 func (h *Handler) UpdateProfile(c *gin.Context) {
-	logger := getLogger(c)
-
 	var user model.User
 
-	if err := c.BindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bind(c, &user, binding.JSON) {
 		return
 	}
 
-	err := h.s.UpdateProfile(c.Request.Context(), c.Param("id"), &user)
+	pending, err := h.s.UpdateProfile(c.Request.Context(), c.Param("id"), &user)
 	if err != nil {
-		if errors.Is(err, service.ErrUserDoesNotExists) {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-		}
-		logger.Error("/users/profile/{id}", zap.Error(fmt.Errorf("update profile failed: %w", err)))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		abort(c, fmt.Errorf("update profile failed: %w", err))
 		return
 	}
 
-	c.Status(200)
+	c.JSON(http.StatusOK, gin.H{
+		"pending": pending,
+	})
+}
+
+// @Summary confirm new email
+// @Tags user
+// @Param input body service.ChangeConfirm true "code sent to the new email"
+// @Param id path int true "user's id"
+// @Accept json
+// @Success 200
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 429 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/profile/{id}/email/confirm [POST]
+// @Security Bearer
+func (h *Handler) ConfirmEmail(c *gin.Context) {
+	h.confirmChange(c, service.FieldEmail)
+}
+
+// @Summary confirm new phone number
+// @Tags user
+// @Param input body service.ChangeConfirm true "code sent to the new phone number"
+// @Param id path int true "user's id"
+// @Accept json
+// @Success 200
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 429 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /users/profile/{id}/phone/confirm [POST]
+// @Security Bearer
+func (h *Handler) ConfirmPhone(c *gin.Context) {
+	h.confirmChange(c, service.FieldPhone)
+}
+
+func (h *Handler) confirmChange(c *gin.Context, field string) {
+	var confirm service.ChangeConfirm
+
+	if !bind(c, &confirm, binding.JSON) {
+		return
+	}
+
+	err := h.s.ConfirmChange(c.Request.Context(), c.Param("id"), field, confirm.Code)
+	if err != nil {
+		abort(c, fmt.Errorf("confirm change failed: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
 }
 
 // @Summary delete user
@@ -88,26 +125,16 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 // @Param id path int false "user's id to delete"
 // @Accept json
 // @Success 200
-// @Failure 401 {object} error "error: err"
-// @Failure 403 {object} error "error: err"
-// @Failure 500 {object} error "error: err"
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /users/{id} [DELETE]
 // @Security Bearer
 func (h *Handler) DeleteUser(c *gin.Context) {
-	logger := getLogger(c)
-
 	err := h.s.DeleteUser(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		if errors.Is(err, service.ErrUserDoesNotExists) {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-			return
-		}
-		logger.Error("/users/{id}", zap.Error(fmt.Errorf("delete user failed: %w", err)))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		abort(c, fmt.Errorf("delete user failed: %w", err))
 		return
 	}
 	c.Status(200)
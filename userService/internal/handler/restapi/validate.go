@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+)
+
+// validations are the tags of the profile fields. The values are checked the
+// way the service normalizes them, so "+7 (900) 123-45-67" is a phone number.
+var validations = map[string]func(string) bool{
+	"phone": func(phone string) bool {
+		return service.ValidPhone(service.NormalizePhone(phone))
+	},
+	"mail": func(email string) bool {
+		return service.ValidEmail(service.NormalizeEmail(email))
+	},
+	"name": func(name string) bool {
+		return service.ValidName(service.NormalizeName(name))
+	},
+	"password": service.ValidPassword,
+}
+
+var messages = map[string]string{
+	"required":         "is required",
+	"required_without": "is required",
+	"phone":            "must be a phone number in E.164 format",
+	"mail":             "must be an email address of up to 30 characters",
+	"name":             "must be up to 30 letters, spaces, hyphens and apostrophes",
+	"password":         "must be 8 to 72 characters with a lowercase letter, an uppercase letter and a digit",
+	"numeric":          "must be a number",
+	"len":              "must be %v characters long",
+	"min":              "must be at least %v",
+	"max":              "must be at most %v",
+	"oneof":            "must be one of %v",
+}
+
+// registerValidations adds the tags of the profile fields to the validator of
+// gin and names the fields of the errors by their json or form keys.
+func registerValidations() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.Split(field.Tag.Get(tag), ",")[0]
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	for tag, valid := range validations {
+		valid := valid
+		_ = v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return valid(fl.Field().String())
+		})
+	}
+}
+
+// bind binds the request with b. Malformed input is answered with 400, input
+// which breaks the rules of its fields with 422 and the error of every field.
+func bind(c *gin.Context, obj interface{}, b binding.Binding) bool {
+	err := c.ShouldBindWith(obj, b)
+	if err == nil {
+		return true
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		abort(c, service.ErrMalformedRequest)
+		return false
+	}
+
+	fields := make(map[string]string, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		msg, ok := messages[fieldErr.Tag()]
+		if !ok {
+			msg = "is invalid"
+		}
+		if strings.Contains(msg, "%v") {
+			msg = fmt.Sprintf(msg, fieldErr.Param())
+		}
+		fields[fieldErr.Field()] = msg
+	}
+
+	abort(c, service.ErrValidation.WithDetails(map[string]interface{}{
+		"fields": fields,
+	}))
+	return false
+}
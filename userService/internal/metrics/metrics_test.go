@@ -0,0 +1,43 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/go-redis/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/RipperAcskt/innotaxi/internal/metrics"
+)
+
+type pool struct {
+	stats redis.PoolStats
+}
+
+func (p *pool) PoolStats() *redis.PoolStats {
+	return &p.stats
+}
+
+func TestRegisterRedis(t *testing.T) {
+	p := &pool{redis.PoolStats{Hits: 10, Misses: 2, TotalConns: 3, IdleConns: 1}}
+
+	err := metrics.RegisterRedis(p)
+	assert.Equal(t, err, nil)
+
+	expected := `
+# HELP innotaxi_redis_pool_hits_total Times a free connection was found in the pool.
+# TYPE innotaxi_redis_pool_hits_total counter
+innotaxi_redis_pool_hits_total 10
+# HELP innotaxi_redis_pool_idle_connections Idle connections in the pool.
+# TYPE innotaxi_redis_pool_idle_connections gauge
+innotaxi_redis_pool_idle_connections 1
+# HELP innotaxi_redis_pool_total_connections Connections in the pool.
+# TYPE innotaxi_redis_pool_total_connections gauge
+innotaxi_redis_pool_total_connections 3
+`
+	err = testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected),
+		"innotaxi_redis_pool_hits_total", "innotaxi_redis_pool_idle_connections", "innotaxi_redis_pool_total_connections")
+	assert.Equal(t, err, nil)
+}
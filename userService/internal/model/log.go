@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// Log is a log entry of the service. Fields are the fields zap wrote for the
+// entry, like level, msg and request_id.
+type Log struct {
+	ID     string                 `json:"id"`
+	Time   time.Time              `json:"time"`
+	Fields map[string]interface{} `json:"fields"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+const (
+	OrderStatusInProgress string = "in progress"
+	OrderStatusFinished   string = "finished"
+)
+
+const (
+	TaxiTypeEconomy  string = "economy"
+	TaxiTypeComfort  string = "comfort"
+	TaxiTypeBusiness string = "business"
+)
+
+var TaxiTypes = []string{TaxiTypeEconomy, TaxiTypeComfort, TaxiTypeBusiness}
+
+type Order struct {
+	ID         uint64     `json:"id"`
+	UserID     uint64     `json:"user_id"`
+	DriverID   string     `json:"driver_id"`
+	TaxiType   string     `json:"taxi_type"`
+	From       string     `json:"from"`
+	To         string     `json:"to"`
+	Date       time.Time  `json:"date"`
+	Status     string     `json:"status"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Rating     int        `json:"rating"`
+}
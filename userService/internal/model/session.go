@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Session is a sign in of the user on one device. Its ID is the family of
+// the refresh tokens rotated from the sign in.
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    uint64    `json:"user_id"`
+	Device    string    `json:"device"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	IssuedAt  time.Time `json:"issued_at"`
+	Current   bool      `json:"current"`
+}
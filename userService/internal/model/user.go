@@ -1,15 +1,28 @@
 package model
 
 const (
+	StatusPending string = "pending"
 	StatusCreated string = "created"
 	StatusDeleted string = "deleted"
 )
 
 type User struct {
 	ID          uint64  `json:"-"`
+	Name        string  `json:"name" binding:"omitempty,name"`
+	PhoneNumber string  `json:"phone_number" binding:"omitempty,phone"`
+	Email       string  `json:"email" binding:"omitempty,mail"`
+	Raiting     float64 `json:"raiting"`
+	Status      string  `json:"-"`
+}
+
+// UserRecord is the user as admins see it, with the fields hidden from the
+// profile.
+type UserRecord struct {
+	ID          uint64  `json:"id"`
 	Name        string  `json:"name"`
 	PhoneNumber string  `json:"phone_number"`
 	Email       string  `json:"email"`
 	Raiting     float64 `json:"raiting"`
-	Status      string  `json:"-"`
+	Status      string  `json:"status"`
+	Role        string  `json:"role"`
 }
@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/config"
+)
+
+// SMTPMailer sends emails through the SMTP server of SMTP_HOST (host:port).
+// The connection is upgraded with STARTTLS when the server supports it.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(cfg *config.Config) *SMTPMailer {
+	var auth smtp.Auth
+	if cfg.SMTP_USERNAME != "" {
+		host, _, _ := net.SplitHostPort(cfg.SMTP_HOST)
+		auth = smtp.PlainAuth("", cfg.SMTP_USERNAME, cfg.SMTP_PASSWORD, host)
+	}
+	return &SMTPMailer{cfg.SMTP_HOST, cfg.SMTP_FROM, auth}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return fmt.Errorf("dial context failed: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(30 * time.Second)
+	}
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return fmt.Errorf("set deadline failed: %w", err)
+	}
+
+	host, _, _ := net.SplitHostPort(m.addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return fmt.Errorf("new client failed: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return fmt.Errorf("start tls failed: %w", err)
+		}
+	}
+	if m.auth != nil {
+		err = client.Auth(m.auth)
+		if err != nil {
+			return fmt.Errorf("auth failed: %w", err)
+		}
+	}
+
+	err = client.Mail(m.from)
+	if err != nil {
+		return fmt.Errorf("mail failed: %w", err)
+	}
+	err = client.Rcpt(to)
+	if err != nil {
+		return fmt.Errorf("rcpt failed: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data failed: %w", err)
+	}
+	_, err = w.Write(message(m.from, to, subject, body))
+	if err != nil {
+		return fmt.Errorf("write failed: %w", err)
+	}
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("close failed: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func message(from, to, subject, body string) []byte {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(msg.String())
+}
+
+// LogMailer is used instead of SMTPMailer when SMTP_HOST isn't set: emails are
+// appended to the file of MAIL_LOG_PATH, or written to stdout if it isn't set.
+// Like LogSms it keeps the codes out of the app logger.
+type LogMailer struct {
+	path   string
+	stdout io.Writer
+	mu     sync.Mutex
+}
+
+func NewLogMailer(cfg *config.Config) *LogMailer {
+	return &LogMailer{path: cfg.MAIL_LOG_PATH, stdout: os.Stdout}
+}
+
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return appendLine(m.path, m.stdout, to, subject, strings.ReplaceAll(body, "\n", " "))
+}
+
+// Mail is an email sent through MemoryMailer.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MemoryMailer keeps the emails in memory instead of sending them, for tests
+// and local runs.
+type MemoryMailer struct {
+	mu    sync.Mutex
+	mails []Mail
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mails = append(m.mails, Mail{to, subject, body})
+	return nil
+}
+
+// Mails returns the emails sent so far.
+func (m *MemoryMailer) Mails() []Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Mail(nil), m.mails...)
+}
@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/config"
+)
+
+// LogSms stands in for an SMS gateway: messages are appended to the file of
+// SMS_LOG_PATH, or written to stdout if it isn't set. The messages carry OTP
+// codes and reset tokens, so they never go through the app logger, whose
+// entries are stored in Mongo and served to admins.
+type LogSms struct {
+	path   string
+	stdout io.Writer
+	mu     sync.Mutex
+}
+
+func NewLogSms(cfg *config.Config) *LogSms {
+	return &LogSms{path: cfg.SMS_LOG_PATH, stdout: os.Stdout}
+}
+
+func (s *LogSms) Send(ctx context.Context, phone, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return appendLine(s.path, s.stdout, phone, text)
+}
+
+// appendLine writes the fields tab separated after the current time to the
+// file of path, or to stdout if path is empty.
+func appendLine(path string, stdout io.Writer, fields ...string) error {
+	out := stdout
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("open file failed: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	line := time.Now().UTC().Format(time.RFC3339)
+	for _, field := range fields {
+		line += "\t" + field
+	}
+
+	_, err := fmt.Fprintln(out, line)
+	if err != nil {
+		return fmt.Errorf("fprintln failed: %w", err)
+	}
+	return nil
+}
+
+// Sms is a message sent through MemorySms.
+type Sms struct {
+	Phone string
+	Text  string
+}
+
+// MemorySms keeps the messages in memory instead of sending them, for tests
+// and local runs.
+type MemorySms struct {
+	mu       sync.Mutex
+	messages []Sms
+}
+
+func NewMemorySms() *MemorySms {
+	return &MemorySms{}
+}
+
+func (s *MemorySms) Send(ctx context.Context, phone, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, Sms{phone, text})
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (s *MemorySms) Messages() []Sms {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Sms(nil), s.messages...)
+}
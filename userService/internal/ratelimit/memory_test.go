@@ -0,0 +1,61 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/internal/ratelimit"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestMemoryTake(t *testing.T) {
+	limiter := ratelimit.NewMemory()
+	limit := ratelimit.Limit{Rate: 2, Burst: 2}
+
+	test := []struct {
+		name      string
+		key       string
+		allowed   bool
+		remaining int
+	}{
+		{
+			name:      "first request",
+			key:       "ip:10.0.0.1",
+			allowed:   true,
+			remaining: 1,
+		},
+		{
+			name:      "last request of burst",
+			key:       "ip:10.0.0.1",
+			allowed:   true,
+			remaining: 0,
+		},
+		{
+			name:      "bucket is empty",
+			key:       "ip:10.0.0.1",
+			allowed:   false,
+			remaining: 0,
+		},
+		{
+			name:      "other key",
+			key:       "user:1",
+			allowed:   true,
+			remaining: 1,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := limiter.Take(context.Background(), tt.key, limit)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, res.Allowed, tt.allowed)
+			assert.Equal(t, res.Remaining, tt.remaining)
+			assert.Equal(t, res.Limit, 2)
+			if !tt.allowed {
+				assert.Equal(t, res.RetryAfter > 29*time.Second && res.RetryAfter <= 30*time.Second, true)
+				assert.Equal(t, res.Headers()["Retry-After"], "30")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Limit is a token bucket: it holds up to Burst requests and is refilled
+// with Rate requests per minute.
+type Limit struct {
+	Rate  int
+	Burst int
+}
+
+// PerMinute returns the limit of n requests per minute which can all be made
+// at once.
+func PerMinute(n int) Limit {
+	return Limit{Rate: n, Burst: n}
+}
+
+// interval returns the time one token takes to refill.
+func (l Limit) interval() time.Duration {
+	return time.Minute / time.Duration(l.Rate)
+}
+
+// Result is the state of a bucket after a request was taken from it.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed, 0 if the
+	// request was allowed.
+	RetryAfter time.Duration
+}
+
+// Headers returns the rate limit headers of the result, Retry-After is set
+// only if the request wasn't allowed.
+func (r *Result) Headers() map[string]string {
+	headers := map[string]string{
+		"RateLimit-Limit":     strconv.Itoa(r.Limit),
+		"RateLimit-Remaining": strconv.Itoa(r.Remaining),
+		"RateLimit-Reset":     seconds(r.Reset),
+	}
+	if !r.Allowed {
+		headers["Retry-After"] = seconds(r.RetryAfter)
+	}
+	return headers
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// Limiter takes requests from the buckets of keys.
+type Limiter interface {
+	Take(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// take takes a request from the bucket which had tokens at updated and
+// returns the tokens left with the result.
+func take(tokens float64, updated, now time.Time, limit Limit) (float64, *Result) {
+	tokens += float64(now.Sub(updated)) / float64(limit.interval())
+	if tokens > float64(limit.Burst) {
+		tokens = float64(limit.Burst)
+	}
+
+	res := &Result{Limit: limit.Burst}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((1 - tokens) * float64(limit.interval()))
+	}
+	res.Remaining = int(tokens)
+	res.Reset = time.Duration((float64(limit.Burst) - tokens) * float64(limit.interval()))
+	return tokens, res
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS orders;
+
+DROP TYPE IF EXISTS order_states;
+
+DROP TYPE IF EXISTS taxi_types;
@@ -0,0 +1,16 @@
+DROP TYPE IF EXISTS taxi_types;CREATE TYPE taxi_types as enum ('economy', 'comfort', 'business');
+
+DROP TYPE IF EXISTS order_states;CREATE TYPE order_states as enum ('in progress', 'finished');
+
+CREATE TABLE IF NOT EXISTS orders (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    driver_id VARCHAR(64) NOT NULL,
+    taxi_type taxi_types NOT NULL,
+    from_address VARCHAR(255) NOT NULL,
+    to_address VARCHAR(255) NOT NULL,
+    date TIMESTAMP NOT NULL DEFAULT NOW(),
+    status order_states NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS orders_user_id_date_idx ON orders (user_id, date DESC);
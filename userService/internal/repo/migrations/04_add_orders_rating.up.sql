@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS rating INTEGER CHECK (rating BETWEEN 1 AND 5);
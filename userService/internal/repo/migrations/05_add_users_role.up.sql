@@ -0,0 +1,3 @@
+DROP TYPE IF EXISTS roles;CREATE TYPE roles as enum ('user', 'admin');
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role roles NOT NULL DEFAULT 'user';
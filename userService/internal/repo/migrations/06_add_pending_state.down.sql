@@ -0,0 +1,10 @@
+DELETE FROM users WHERE status = 'pending';
+
+ALTER TYPE states RENAME TO states_old;
+CREATE TYPE states as enum ('created', 'deleted');
+
+ALTER TABLE users 
+ALTER COLUMN status TYPE states 
+USING status::varchar::states;
+
+DROP TYPE states_old;
@@ -0,0 +1 @@
+ALTER TYPE states ADD VALUE IF NOT EXISTS 'pending';
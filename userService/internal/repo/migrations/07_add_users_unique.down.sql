@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users_phone_number_pending_key;
+DROP INDEX IF EXISTS users_email_created_key;
+DROP INDEX IF EXISTS users_phone_number_created_key;
@@ -0,0 +1,3 @@
+CREATE UNIQUE INDEX IF NOT EXISTS users_phone_number_created_key ON users (phone_number) WHERE status = 'created';
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_created_key ON users (email) WHERE status = 'created';
+CREATE UNIQUE INDEX IF NOT EXISTS users_phone_number_pending_key ON users (phone_number) WHERE status = 'pending';
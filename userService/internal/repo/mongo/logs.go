@@ -0,0 +1,215 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+)
+
+const (
+	logsCollection = "logs"
+	ttlIndex       = "time_ttl"
+	defaultLogTTL  = 30
+
+	// indexOptionsConflict is the code of the error of creating an index
+	// which exists with other options.
+	indexOptionsConflict = 85
+
+	// timeLayout is the layout of zapcore.ISO8601TimeEncoder.
+	timeLayout = "2006-01-02T15:04:05.000Z0700"
+)
+
+func (m *Mongo) logs() *mongo.Collection {
+	return m.client.Database(m.cfg.MONGO_DB_USERNAME).Collection(logsCollection)
+}
+
+// createIndexes creates the indexes of the logs collection. The entries expire
+// LOG_TTL days after they were written, the TTL index is also the one the
+// time range and the order of the list use.
+func (m *Mongo) createIndexes(ctx context.Context) error {
+	ttl := m.cfg.LOG_TTL
+	if ttl == 0 {
+		ttl = defaultLogTTL
+	}
+	expire := int32(ttl * 24 * 60 * 60)
+
+	_, err := m.logs().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "time", Value: -1}},
+			Options: options.Index().SetName(ttlIndex).SetExpireAfterSeconds(expire),
+		},
+		{
+			Keys: bson.D{{Key: "request_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "level", Value: 1}, {Key: "time", Value: -1}},
+		},
+	})
+	if se, ok := err.(mongo.ServerError); ok && se.HasErrorCode(indexOptionsConflict) {
+		// LOG_TTL changed, the TTL of the existing index is updated in place.
+		err = m.logs().Database().RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: logsCollection},
+			{Key: "index", Value: bson.D{{Key: "name", Value: ttlIndex}, {Key: "expireAfterSeconds", Value: expire}}},
+		}).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("create many failed: %w", err)
+	}
+	return nil
+}
+
+func (m *Mongo) insertLogs(ctx context.Context, logs []interface{}) error {
+	for _, doc := range logs {
+		setLogTime(doc.(map[string]interface{}))
+	}
+
+	_, err := m.logs().InsertMany(ctx, logs, options.InsertMany().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("insert many failed: %w", err)
+	}
+	return nil
+}
+
+// setLogTime replaces the ts string of zap with the time date the TTL index
+// and the queries need.
+func setLogTime(doc map[string]interface{}) {
+	t := time.Now()
+	if ts, ok := doc["ts"].(string); ok {
+		parsed, err := time.Parse(timeLayout, ts)
+		if err == nil {
+			t = parsed
+		}
+		delete(doc, "ts")
+	}
+	doc["time"] = t
+}
+
+func (m *Mongo) GetLogsByFilter(ctx context.Context, filter *service.LogsFilter) ([]*model.Log, error) {
+	query, err := logsQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(logsSort).SetLimit(int64(filter.Limit) + 1)
+	cur, err := m.logs().Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("find failed: %w", err)
+	}
+
+	var docs []bson.M
+	err = cur.All(ctx, &docs)
+	if err != nil {
+		return nil, fmt.Errorf("all failed: %w", err)
+	}
+
+	logs := make([]*model.Log, 0, len(docs))
+	for _, doc := range docs {
+		logs = append(logs, toLog(doc))
+	}
+	return logs, nil
+}
+
+func (m *Mongo) ExportLogs(ctx context.Context, filter *service.LogsFilter, fn func(*model.Log) error) error {
+	query, err := logsQuery(filter)
+	if err != nil {
+		return err
+	}
+
+	cur, err := m.logs().Find(ctx, query, options.Find().SetSort(logsSort))
+	if err != nil {
+		return fmt.Errorf("find failed: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var doc bson.M
+		err = cur.Decode(&doc)
+		if err != nil {
+			return fmt.Errorf("decode failed: %w", err)
+		}
+
+		err = fn(toLog(doc))
+		if err != nil {
+			return err
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return fmt.Errorf("cursor failed: %w", err)
+	}
+	return nil
+}
+
+var logsSort = bson.D{{Key: "time", Value: -1}, {Key: "_id", Value: -1}}
+
+func logsQuery(filter *service.LogsFilter) (bson.M, error) {
+	and := bson.A{}
+
+	if filter.Level != "" {
+		and = append(and, bson.M{"level": filter.Level})
+	}
+
+	period := bson.M{}
+	if !filter.From.IsZero() {
+		period["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		period["$lt"] = filter.To
+	}
+	if len(period) > 0 {
+		and = append(and, bson.M{"time": period})
+	}
+
+	if filter.RequestID != "" {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"request_id": filter.RequestID},
+			bson.M{"uuid": filter.RequestID},
+		}})
+	}
+	if filter.Method != "" {
+		and = append(and, bson.M{"method": filter.Method})
+	}
+	if filter.URL != "" {
+		and = append(and, bson.M{"url": bson.M{"$regex": "^" + regexp.QuoteMeta(filter.URL)}})
+	}
+
+	if filter.After != nil {
+		id, err := primitive.ObjectIDFromHex(filter.After.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cursor id: %v: %w", err, service.ErrBadFilter)
+		}
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"time": bson.M{"$lt": filter.After.Time}},
+			bson.M{"time": filter.After.Time, "_id": bson.M{"$lt": id}},
+		}})
+	}
+
+	if len(and) == 0 {
+		return bson.M{}, nil
+	}
+	return bson.M{"$and": and}, nil
+}
+
+func toLog(doc bson.M) *model.Log {
+	log := &model.Log{Fields: doc}
+	if id, ok := doc["_id"].(primitive.ObjectID); ok {
+		log.ID = id.Hex()
+	}
+	if t, ok := doc["time"].(primitive.DateTime); ok {
+		log.Time = t.Time().UTC()
+	}
+	delete(doc, "_id")
+	delete(doc, "time")
+	return log
+}
@@ -2,32 +2,23 @@ package mongo
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/RipperAcskt/innotaxi/config"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+// Mongo stores the log entries of zap in the logs collection through Sink,
+// which inserts them in batches in the background, and queries them.
 type Mongo struct {
+	*Sink
 	client *mongo.Client
 	cfg    *config.Config
 }
 
-type log struct {
-	Level  string
-	Caller string
-	Msg    string
-	Method string
-	Uuid   string
-	Err    string
-	Time   string
-}
-
 func New(cfg *config.Config) (*Mongo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -41,43 +32,34 @@ func New(cfg *config.Config) (*Mongo, error) {
 		return nil, fmt.Errorf("ping failed: %w", err)
 	}
 
-	return &Mongo{client, cfg}, nil
-}
-
-func (m *Mongo) Close() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	err := m.client.Disconnect(ctx)
+	m := &Mongo{client: client, cfg: cfg}
+	err = m.createIndexes(ctx)
 	if err != nil {
-		return fmt.Errorf("disconnect failed: %w", err)
+		return nil, fmt.Errorf("create indexes failed: %w", err)
 	}
-	return nil
+
+	m.Sink = NewSink(m.insertLogs, SinkOptions{
+		BatchSize:     cfg.LOG_BATCH_SIZE,
+		FlushInterval: time.Duration(cfg.LOG_FLUSH_INTERVAL) * time.Second,
+		QueueSize:     cfg.LOG_QUEUE_SIZE,
+		Policy:        cfg.LOG_QUEUE_POLICY,
+	})
+	return m, nil
 }
 
-func (m *Mongo) Write(p []byte) (n int, err error) {
-	var logs log
-	err = json.Unmarshal(p, &logs)
+// Close inserts the queued log entries and disconnects.
+func (m *Mongo) Close() error {
+	err := m.Sink.Close()
 	if err != nil {
-		return 0, fmt.Errorf("unmarshal failed: %w", err)
+		return fmt.Errorf("sink close failed: %w", err)
 	}
 
-	logger := m.client.Database(m.cfg.MONGO_DB_USERNAME).Collection("logs")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	_, err = logger.InsertOne(ctx, bson.M{
-		"level":  logs.Level,
-		"caller": logs.Caller,
-		"msg":    logs.Msg,
-		"method": logs.Method,
-		"uuid":   logs.Uuid,
-		"error":  logs.Err,
-		"time":   logs.Time,
-	})
+
+	err = m.client.Disconnect(ctx)
 	if err != nil {
-		return
+		return fmt.Errorf("disconnect failed: %w", err)
 	}
-
-	return len(p), nil
+	return nil
 }
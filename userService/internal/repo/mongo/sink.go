@@ -0,0 +1,218 @@
+package mongo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// PolicyDrop drops the entries written while the queue is full.
+	PolicyDrop = "drop"
+	// PolicyBlock makes writers wait until the queue has room.
+	PolicyBlock = "block"
+
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	defaultQueueSize     = 10000
+
+	insertTimeout = 5 * time.Second
+)
+
+var ErrSinkClosed = fmt.Errorf("sink is closed")
+
+// InsertFunc stores a batch of documents.
+type InsertFunc func(ctx context.Context, docs []interface{}) error
+
+type SinkOptions struct {
+	// BatchSize is the number of entries which are inserted at once.
+	BatchSize int
+	// FlushInterval is the longest time an entry waits for its batch.
+	FlushInterval time.Duration
+	// QueueSize is the number of entries waiting to be inserted, Policy
+	// tells what happens to the entries written when it's reached.
+	QueueSize int
+	Policy    string
+	// ErrorOutput gets the insert failures and the number of dropped
+	// entries, os.Stderr if nil. It can't be the logger the sink writes for.
+	ErrorOutput io.Writer
+}
+
+// Sink is a zap WriteSyncer which stores the JSON entries of zap as
+// documents with every field of the entry. Writes only put the entries into
+// a bounded queue, they are inserted in batches by a goroutine once a batch
+// is full or FlushInterval passes.
+type Sink struct {
+	insert InsertFunc
+	opts   SinkOptions
+
+	queue chan map[string]interface{}
+	flush chan chan struct{}
+	// stop wakes the writers up on Close, quit tells run to insert what's
+	// left in the queue and return once no writer can send to it.
+	stop chan struct{}
+	quit chan struct{}
+	done chan struct{}
+
+	mu        sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+	dropped   uint64
+}
+
+func NewSink(insert InsertFunc, opts SinkOptions) *Sink {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultFlushInterval
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+	if opts.Policy != PolicyBlock {
+		opts.Policy = PolicyDrop
+	}
+	if opts.ErrorOutput == nil {
+		opts.ErrorOutput = os.Stderr
+	}
+
+	s := &Sink{
+		insert: insert,
+		opts:   opts,
+		queue:  make(chan map[string]interface{}, opts.QueueSize),
+		flush:  make(chan chan struct{}),
+		stop:   make(chan struct{}),
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Write queues the entry p. An entry which doesn't fit the queue is dropped
+// or waits for room, as Policy says.
+func (s *Sink) Write(p []byte) (int, error) {
+	var doc map[string]interface{}
+	err := json.Unmarshal(p, &doc)
+	if err != nil {
+		return 0, fmt.Errorf("unmarshal failed: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return 0, ErrSinkClosed
+	}
+
+	if s.opts.Policy == PolicyBlock {
+		select {
+		case s.queue <- doc:
+			return len(p), nil
+		case <-s.stop:
+			return 0, ErrSinkClosed
+		}
+	}
+
+	select {
+	case s.queue <- doc:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return len(p), nil
+}
+
+// Sync inserts the queued entries and waits for it.
+func (s *Sink) Sync() error {
+	done := make(chan struct{})
+	select {
+	case s.flush <- done:
+	case <-s.done:
+		return nil
+	}
+	<-done
+	return nil
+}
+
+// Close inserts the queued entries and stops the sink. Writes after Close
+// fail with ErrSinkClosed.
+func (s *Sink) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+
+		close(s.quit)
+	})
+	<-s.done
+	return nil
+}
+
+func (s *Sink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]interface{}, 0, s.opts.BatchSize)
+	for {
+		select {
+		case doc := <-s.queue:
+			batch = append(batch, doc)
+			if len(batch) == s.opts.BatchSize {
+				batch = s.write(batch)
+			}
+		case <-ticker.C:
+			batch = s.write(batch)
+		case done := <-s.flush:
+			batch = s.write(s.drain(batch))
+			close(done)
+		case <-s.quit:
+			s.write(s.drain(batch))
+			return
+		}
+	}
+}
+
+// drain moves the queued entries to batch, inserting every full batch.
+func (s *Sink) drain(batch []interface{}) []interface{} {
+	for {
+		select {
+		case doc := <-s.queue:
+			batch = append(batch, doc)
+			if len(batch) == s.opts.BatchSize {
+				batch = s.write(batch)
+			}
+		default:
+			return batch
+		}
+	}
+}
+
+// write inserts batch and returns it emptied. Failures can't be logged with
+// zap, the sink is one of its outputs, so they go to ErrorOutput.
+func (s *Sink) write(batch []interface{}) []interface{} {
+	if dropped := atomic.SwapUint64(&s.dropped, 0); dropped > 0 {
+		fmt.Fprintf(s.opts.ErrorOutput, "mongo log sink: %d entries dropped, queue is full\n", dropped)
+	}
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), insertTimeout)
+	defer cancel()
+
+	err := s.insert(ctx, batch)
+	if err != nil {
+		fmt.Fprintf(s.opts.ErrorOutput, "mongo log sink: insert of %d entries failed: %v\n", len(batch), err)
+	}
+	return batch[:0]
+}
@@ -0,0 +1,173 @@
+package mongo_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/internal/repo/mongo"
+	"github.com/go-playground/assert/v2"
+)
+
+type store struct {
+	mu      sync.Mutex
+	batches [][]interface{}
+	// wait blocks the inserts until it's closed, if set.
+	wait chan struct{}
+}
+
+func (s *store) insert(ctx context.Context, docs []interface{}) error {
+	if s.wait != nil {
+		<-s.wait
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]interface{}(nil), docs...))
+	return nil
+}
+
+func (s *store) sizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sizes := make([]int, 0, len(s.batches))
+	for _, batch := range s.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+const entry = `{"level":"info","ts":"2023-03-10T12:00:00.000Z","msg":"request","request_id":"42","status":200,"latency":0.001}` + "\n"
+
+func TestSink(t *testing.T) {
+	test := []struct {
+		name  string
+		opts  mongo.SinkOptions
+		write int
+		flush func(s *mongo.Sink)
+		want  []int
+	}{
+		{
+			name:  "full batches",
+			opts:  mongo.SinkOptions{BatchSize: 2, FlushInterval: time.Hour},
+			write: 4,
+			flush: func(s *mongo.Sink) {
+				_ = s.Close()
+			},
+			want: []int{2, 2},
+		},
+		{
+			name:  "close",
+			opts:  mongo.SinkOptions{BatchSize: 10, FlushInterval: time.Hour},
+			write: 3,
+			flush: func(s *mongo.Sink) {
+				_ = s.Close()
+			},
+			want: []int{3},
+		},
+		{
+			name:  "sync",
+			opts:  mongo.SinkOptions{BatchSize: 10, FlushInterval: time.Hour},
+			write: 3,
+			flush: func(s *mongo.Sink) {
+				_ = s.Sync()
+			},
+			want: []int{3},
+		},
+		{
+			name:  "interval",
+			opts:  mongo.SinkOptions{BatchSize: 10, FlushInterval: 10 * time.Millisecond},
+			write: 3,
+			flush: func(s *mongo.Sink) {
+				time.Sleep(100 * time.Millisecond)
+			},
+			want: []int{3},
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &store{}
+			sink := mongo.NewSink(store.insert, tt.opts)
+
+			for i := 0; i < tt.write; i++ {
+				n, err := sink.Write([]byte(entry))
+				assert.Equal(t, err, nil)
+				assert.Equal(t, n, len(entry))
+			}
+			tt.flush(sink)
+
+			assert.Equal(t, store.sizes(), tt.want)
+		})
+	}
+}
+
+func TestSinkFields(t *testing.T) {
+	store := &store{}
+	sink := mongo.NewSink(store.insert, mongo.SinkOptions{})
+
+	_, err := sink.Write([]byte(entry))
+	assert.Equal(t, err, nil)
+	_ = sink.Close()
+
+	doc := store.batches[0][0].(map[string]interface{})
+	assert.Equal(t, doc["request_id"], "42")
+	assert.Equal(t, doc["status"], float64(200))
+	assert.Equal(t, doc["latency"], 0.001)
+}
+
+func TestSinkDrop(t *testing.T) {
+	store := &store{wait: make(chan struct{})}
+	errOut := &bytes.Buffer{}
+	sink := mongo.NewSink(store.insert, mongo.SinkOptions{BatchSize: 1, QueueSize: 1, Policy: mongo.PolicyDrop, ErrorOutput: errOut})
+
+	// The first entry is taken by the blocked insert and the second one
+	// fills the queue, so the third one is dropped.
+	for i := 0; i < 3; i++ {
+		_, err := sink.Write([]byte(entry))
+		assert.Equal(t, err, nil)
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(store.wait)
+	_ = sink.Close()
+
+	assert.Equal(t, store.sizes(), []int{1, 1})
+	assert.Equal(t, strings.Contains(errOut.String(), "1 entries dropped"), true)
+}
+
+func TestSinkBlock(t *testing.T) {
+	store := &store{wait: make(chan struct{})}
+	sink := mongo.NewSink(store.insert, mongo.SinkOptions{BatchSize: 1, QueueSize: 1, Policy: mongo.PolicyBlock})
+
+	for i := 0; i < 2; i++ {
+		_, err := sink.Write([]byte(entry))
+		assert.Equal(t, err, nil)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	written := make(chan error)
+	go func() {
+		_, err := sink.Write([]byte(entry))
+		written <- err
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("write didn't wait for the queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(store.wait)
+	assert.Equal(t, <-written, nil)
+	_ = sink.Close()
+
+	assert.Equal(t, store.sizes(), []int{1, 1, 1})
+
+	_, err := sink.Write([]byte(entry))
+	assert.Equal(t, errors.Is(err, mongo.ErrSinkClosed), true)
+}
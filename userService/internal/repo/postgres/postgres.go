@@ -3,7 +3,9 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/RipperAcskt/innotaxi/config"
@@ -13,6 +15,7 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
@@ -60,33 +63,79 @@ func (p *Postgres) Close() error {
 	return p.DB.Close()
 }
 
+// uniqueViolation is the SQLSTATE of a unique index violation.
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether err is a violation of the unique indexes
+// on the phone numbers and emails of the users.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+// CreateUser adds a pending user. The check for an existing user only saves
+// the insert, the unique indexes reject concurrent sign ups of the same
+// phone number and the activation of an email or phone number taken
+// meanwhile.
 func (p *Postgres) CreateUser(ctx context.Context, user service.UserSingUp) error {
 	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var name string
-	err := p.DB.QueryRowContext(queryCtx, "SELECT name FROM users WHERE (phone_number = $1 OR email = $2) AND status = $3", user.PhoneNumber, user.Email, model.StatusCreated).Scan(&name)
+	err := queryRowContext(queryCtx, p.DB, "SELECT name FROM users WHERE (phone_number = $1 OR email = $2) AND status = $3", user.PhoneNumber, user.Email, model.StatusCreated).Scan(&name)
 	if err == nil {
 		return fmt.Errorf("user: %v: %w", user.Name, service.ErrUserAlreadyExists)
 
 	}
 
-	_, err = p.DB.ExecContext(ctx, "INSERT INTO users (name, phone_number, email, password, raiting, status) VALUES($1, $2, $3, $4, 0.0, $5)", user.Name, user.PhoneNumber, user.Email, []byte(user.Password), model.StatusCreated)
+	// Sign up of a number which hasn't been verified yet replaces the
+	// pending user, so nobody can hold a number they don't own.
+	_, err = execContext(queryCtx, p.DB, "DELETE FROM users WHERE phone_number = $1 AND status = $2", user.PhoneNumber, model.StatusPending)
+	if err != nil {
+		return fmt.Errorf("exec failed: %w", err)
+	}
+
+	_, err = execContext(queryCtx, p.DB, "INSERT INTO users (name, phone_number, email, password, raiting, status) VALUES($1, $2, $3, $4, 0.0, $5)", user.Name, user.PhoneNumber, user.Email, []byte(user.Password), model.StatusPending)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("user: %v: %w", user.Name, service.ErrUserAlreadyExists)
+		}
 		return fmt.Errorf("exec failed: %w", err)
 	}
 	return nil
 }
 
+func (p *Postgres) ActivateUserByPhoneNumber(ctx context.Context, phone string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := execContext(queryCtx, p.DB, "UPDATE users SET status = $1 WHERE phone_number = $2 AND status = $3", model.StatusCreated, phone, model.StatusPending)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("phone number: %v: %w", phone, service.ErrUserAlreadyExists)
+		}
+		return fmt.Errorf("exec context failed: %w", err)
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+	if num == 0 {
+		return service.ErrUserDoesNotExists
+	}
+	return nil
+}
+
 func (p *Postgres) CheckUserByPhoneNumber(ctx context.Context, phone_number string) (*service.UserSingIn, error) {
 	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	row := p.DB.QueryRowContext(queryCtx, "SELECT id, phone_number, password FROM users WHERE phone_number = $1 AND status = $2", phone_number, model.StatusCreated)
+	row := queryRowContext(queryCtx, p.DB, "SELECT id, phone_number, password, role FROM users WHERE phone_number = $1 AND status = $2", phone_number, model.StatusCreated)
 
 	var user service.UserSingIn
 
-	err := row.Scan(&user.ID, &user.PhoneNumber, &user.Password)
+	err := row.Scan(&user.ID, &user.PhoneNumber, &user.Password, &user.Role)
 	if err != nil {
 
 		if err == sql.ErrNoRows {
@@ -99,12 +148,58 @@ func (p *Postgres) CheckUserByPhoneNumber(ctx context.Context, phone_number stri
 	return &user, nil
 }
 
+func (p *Postgres) CheckUserByEmail(ctx context.Context, email string) (*service.UserSingIn, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := queryRowContext(queryCtx, p.DB, "SELECT id, phone_number, password, role FROM users WHERE email = $1 AND status = $2", email, model.StatusCreated)
+
+	var user service.UserSingIn
+
+	err := row.Scan(&user.ID, &user.PhoneNumber, &user.Password, &user.Role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, service.ErrUserDoesNotExists
+		}
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (p *Postgres) GetPasswordById(ctx context.Context, id uint64) (string, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var password string
+	err := queryRowContext(queryCtx, p.DB, "SELECT password FROM users WHERE id = $1 AND status = $2", id, model.StatusCreated).Scan(&password)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", service.ErrUserDoesNotExists
+		}
+		return "", fmt.Errorf("query row context failed: %w", err)
+	}
+
+	return password, nil
+}
+
+func (p *Postgres) UpdatePasswordById(ctx context.Context, id uint64, password string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := execContext(queryCtx, p.DB, "UPDATE users SET password = $1 WHERE id = $2", []byte(password), id)
+	if err != nil {
+		return fmt.Errorf("exec context failed: %w", err)
+	}
+	return nil
+}
+
 func (p *Postgres) GetUserById(ctx context.Context, id string) (*model.User, error) {
 	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	user := &model.User{}
-	err := p.DB.QueryRowContext(queryCtx, "SELECT id, name, phone_number, email, raiting FROM users WHERE id = $1 AND status = $2", id, model.StatusCreated).Scan(&user.ID, &user.Name, &user.PhoneNumber, &user.Email, &user.Raiting)
+	err := queryRowContext(queryCtx, p.DB, "SELECT id, name, phone_number, email, raiting FROM users WHERE id = $1 AND status = $2", id, model.StatusCreated).Scan(&user.ID, &user.Name, &user.PhoneNumber, &user.Email, &user.Raiting)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, service.ErrUserDoesNotExists
@@ -130,8 +225,11 @@ func (p *Postgres) UpdateUserById(ctx context.Context, id string, user *model.Us
 		transfer.Email = &user.Email
 	}
 
-	res, err := p.DB.ExecContext(queryCtx, "UPDATE users SET name = COALESCE($1, name), phone_number = COALESCE($2, phone_number), email = COALESCE($3, email) WHERE id = $4", transfer.Name, transfer.PhoneNumber, transfer.Email, user.ID)
+	res, err := execContext(queryCtx, p.DB, "UPDATE users SET name = COALESCE($1, name), phone_number = COALESCE($2, phone_number), email = COALESCE($3, email) WHERE id = $4 AND status = $5", transfer.Name, transfer.PhoneNumber, transfer.Email, id, model.StatusCreated)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("user: %v: %w", id, service.ErrUserAlreadyExists)
+		}
 		return fmt.Errorf("exec context failed: %w", err)
 	}
 
@@ -149,8 +247,122 @@ func (p *Postgres) DeleteUserById(ctx context.Context, id string) error {
 	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	res, err := p.DB.ExecContext(queryCtx, "UPDATE users SET status = $1 WHERE id = $2 AND status = $3", model.StatusDeleted, id, model.StatusCreated)
+	res, err := execContext(queryCtx, p.DB, "UPDATE users SET status = $1 WHERE id = $2 AND status = $3", model.StatusDeleted, id, model.StatusCreated)
+	if err != nil {
+		return fmt.Errorf("exec context failed: %w", err)
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+	if num == 0 {
+		return service.ErrUserDoesNotExists
+	}
+	return nil
+}
+
+var usersSortColumns = map[string]string{
+	service.SortById:     "id",
+	service.SortByName:   "name",
+	service.SortByRating: "raiting",
+}
+
+// likePrefix escapes the LIKE wildcards of prefix and appends one.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix) + "%"
+}
+
+// GetUsersByFilter pages through the users with keyset pagination: the page
+// starts after the cursor's sort value and id, so rows added or deleted
+// meanwhile don't shift it.
+func (p *Postgres) GetUsersByFilter(ctx context.Context, filter *service.UsersFilter) ([]*model.UserRecord, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	where := make([]string, 0)
+	args := make([]interface{}, 0)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != "" {
+		where = append(where, "status = "+arg(filter.Status))
+	}
+	if filter.Name != "" {
+		where = append(where, "name ILIKE "+arg(likePrefix(filter.Name)))
+	}
+	if filter.Phone != "" {
+		where = append(where, "phone_number LIKE "+arg(likePrefix(filter.Phone)))
+	}
+	if filter.Email != "" {
+		where = append(where, "email ILIKE "+arg(likePrefix(filter.Email)))
+	}
+	if filter.MinRating != nil {
+		where = append(where, "raiting >= "+arg(*filter.MinRating))
+	}
+	if filter.MaxRating != nil {
+		where = append(where, "raiting <= "+arg(*filter.MaxRating))
+	}
+
+	column, ok := usersSortColumns[filter.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("sort %v: %w", filter.SortBy, service.ErrBadFilter)
+	}
+	order, cmp := "ASC", ">"
+	if filter.Desc {
+		order, cmp = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		switch filter.SortBy {
+		case service.SortByName:
+			where = append(where, fmt.Sprintf("(name, id) %s (%s, %s)", cmp, arg(filter.After.Name), arg(filter.After.ID)))
+		case service.SortByRating:
+			where = append(where, fmt.Sprintf("(raiting, id) %s (%s, %s)", cmp, arg(filter.After.Rating), arg(filter.After.ID)))
+		default:
+			where = append(where, fmt.Sprintf("id %s %s", cmp, arg(filter.After.ID)))
+		}
+	}
+
+	query := "SELECT id, name, phone_number, email, raiting, status, role FROM users"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT %s", column, order, order, arg(filter.Limit+1))
+
+	rows, err := queryContext(queryCtx, p.DB, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query context failed: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*model.UserRecord, 0)
+	for rows.Next() {
+		user := &model.UserRecord{}
+		err := rows.Scan(&user.ID, &user.Name, &user.PhoneNumber, &user.Email, &user.Raiting, &user.Status, &user.Role)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows failed: %w", err)
+	}
+
+	return users, nil
+}
+
+func (p *Postgres) RestoreUserById(ctx context.Context, id string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := execContext(queryCtx, p.DB, "UPDATE users SET status = $1 WHERE id = $2 AND status = $3", model.StatusCreated, id, model.StatusDeleted)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("user: %v: %w", id, service.ErrUserAlreadyExists)
+		}
 		return fmt.Errorf("exec context failed: %w", err)
 	}
 
@@ -163,3 +375,118 @@ func (p *Postgres) DeleteUserById(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func (p *Postgres) AddOrder(ctx context.Context, order *model.Order) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := queryRowContext(queryCtx, p.DB, "INSERT INTO orders (user_id, driver_id, taxi_type, from_address, to_address, date, status) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id", order.UserID, order.DriverID, order.TaxiType, order.From, order.To, order.Date, order.Status).Scan(&order.ID)
+	if err != nil {
+		return fmt.Errorf("query row context failed: %w", err)
+	}
+	return nil
+}
+
+func (p *Postgres) GetOrdersByUserId(ctx context.Context, id string) ([]*model.Order, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := queryContext(queryCtx, p.DB, "SELECT id, user_id, driver_id, taxi_type, from_address, to_address, date, status, finished_at, COALESCE(rating, 0) FROM orders WHERE user_id = $1 ORDER BY date DESC", id)
+	if err != nil {
+		return nil, fmt.Errorf("query context failed: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]*model.Order, 0)
+	for rows.Next() {
+		order := &model.Order{}
+		err := rows.Scan(&order.ID, &order.UserID, &order.DriverID, &order.TaxiType, &order.From, &order.To, &order.Date, &order.Status, &order.FinishedAt, &order.Rating)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows failed: %w", err)
+	}
+
+	return orders, nil
+}
+
+func (p *Postgres) GetLastOrderByUserId(ctx context.Context, id string) (*model.Order, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	order := &model.Order{}
+	err := queryRowContext(queryCtx, p.DB, "SELECT id, user_id, driver_id, taxi_type, from_address, to_address, date, status, finished_at, COALESCE(rating, 0) FROM orders WHERE user_id = $1 ORDER BY date DESC LIMIT 1", id).Scan(&order.ID, &order.UserID, &order.DriverID, &order.TaxiType, &order.From, &order.To, &order.Date, &order.Status, &order.FinishedAt, &order.Rating)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, service.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("query row context failed: %w", err)
+	}
+
+	return order, nil
+}
+
+// SetOrderRating stores the rating of the order and recomputes the user's
+// rating as the average of all rated orders in the same transaction.
+func (p *Postgres) SetOrderRating(ctx context.Context, order *model.Order, rating int) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := p.DB.BeginTx(queryCtx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := execContext(queryCtx, tx, "UPDATE orders SET rating = $1 WHERE id = $2 AND rating IS NULL", rating, order.ID)
+	if err != nil {
+		return fmt.Errorf("exec context failed: %w", err)
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected failed: %w", err)
+	}
+	if num == 0 {
+		return service.ErrOrderAlreadyRated
+	}
+
+	_, err = execContext(queryCtx, tx, "UPDATE users SET raiting = (SELECT AVG(rating) FROM orders WHERE user_id = $1 AND rating IS NOT NULL) WHERE id = $1", order.UserID)
+	if err != nil {
+		return fmt.Errorf("exec context failed: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit failed: %w", err)
+	}
+	return nil
+}
+
+func (p *Postgres) HasOrderInProgress(ctx context.Context, driverID, userID string) (bool, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var exists bool
+	err := queryRowContext(queryCtx, p.DB, "SELECT EXISTS (SELECT 1 FROM orders WHERE driver_id = $1 AND user_id = $2 AND status = $3)", driverID, userID, model.OrderStatusInProgress).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("scan failed: %w", err)
+	}
+	return exists, nil
+}
+
+// FinishOrderByDriverId finishes the driver's order in progress and records
+// the finish time, the rating window is counted from it.
+func (p *Postgres) FinishOrderByDriverId(ctx context.Context, driverID string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := execContext(queryCtx, p.DB, "UPDATE orders SET status = $1, finished_at = $2 WHERE driver_id = $3 AND status = $4", model.OrderStatusFinished, time.Now().UTC(), driverID, model.OrderStatusInProgress)
+	if err != nil {
+		return fmt.Errorf("exec context failed: %w", err)
+	}
+	return nil
+}
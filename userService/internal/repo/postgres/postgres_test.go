@@ -2,21 +2,26 @@ package postgres_test
 
 import (
 	"context"
+	"database/sql/driver"
+	"errors"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/RipperAcskt/innotaxi/internal/model"
 	"github.com/RipperAcskt/innotaxi/internal/repo/postgres"
 	"github.com/RipperAcskt/innotaxi/internal/service"
 	"github.com/go-playground/assert/v2"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 func TestCreateUser(t *testing.T) {
 	test := []struct {
-		name string
-		user service.UserSingUp
-		err  error
+		name      string
+		user      service.UserSingUp
+		insertErr error
+		err       error
 	}{
 		{
 			name: "add user",
@@ -26,7 +31,19 @@ func TestCreateUser(t *testing.T) {
 				Email:       "ripper@algsdh",
 				Password:    "12345",
 			},
-			err: nil,
+			insertErr: nil,
+			err:       nil,
+		},
+		{
+			name: "concurrent sign up",
+			user: service.UserSingUp{
+				Name:        "Ivan",
+				PhoneNumber: "+7455456",
+				Email:       "ripper@algsdh",
+				Password:    "12345",
+			},
+			insertErr: &pgconn.PgError{Code: "23505"},
+			err:       service.ErrUserAlreadyExists,
 		},
 	}
 
@@ -38,16 +55,61 @@ func TestCreateUser(t *testing.T) {
 			}
 
 			mock.ExpectQuery("SELECT name FROM users").WithArgs(tt.user.PhoneNumber, tt.user.Email, model.StatusCreated).WillReturnError(nil)
-			mock.ExpectExec("INSERT INTO users").WithArgs(tt.user.Name, tt.user.PhoneNumber, tt.user.Email, []byte(tt.user.Password), model.StatusCreated).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("DELETE FROM users").WithArgs(tt.user.PhoneNumber, model.StatusPending).WillReturnResult(sqlmock.NewResult(0, 0))
+			insert := mock.ExpectExec("INSERT INTO users").WithArgs(tt.user.Name, tt.user.PhoneNumber, tt.user.Email, []byte(tt.user.Password), model.StatusPending)
+			if tt.insertErr != nil {
+				insert.WillReturnError(tt.insertErr)
+			} else {
+				insert.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
 
 			postgres := &postgres.Postgres{
 				DB: db,
 			}
 
 			err = postgres.CreateUser(context.Background(), tt.user)
-			assert.Equal(t, err, tt.err)
+			assert.Equal(t, errors.Is(err, tt.err), true)
 			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestActivateUserByPhoneNumber(t *testing.T) {
+	test := []struct {
+		name string
+		rows int64
+		err  error
+	}{
+		{
+			name: "activate user",
+			rows: 1,
+			err:  nil,
+		},
+		{
+			name: "no pending user",
+			rows: 0,
+			err:  service.ErrUserDoesNotExists,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectExec("UPDATE users SET status").WithArgs(model.StatusCreated, "+7455456", model.StatusPending).WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.ActivateUserByPhoneNumber(context.Background(), "+7455456")
 			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
 		})
 	}
 }
@@ -72,9 +134,9 @@ func TestCheckUserByPhoneNumber(t *testing.T) {
 				log.Fatalf("sqlmock new failed: %v", err)
 			}
 
-			rows := sqlmock.NewRows([]string{"id", "phone_number", "password"}).
-				AddRow(1, "123", "123")
-			mock.ExpectQuery("SELECT id, phone_number, password FROM users").WithArgs(tt.phone_number, model.StatusCreated).WillReturnRows(rows)
+			rows := sqlmock.NewRows([]string{"id", "phone_number", "password", "role"}).
+				AddRow(1, "123", "123", "user")
+			mock.ExpectQuery("SELECT id, phone_number, password, role FROM users").WithArgs(tt.phone_number, model.StatusCreated).WillReturnRows(rows)
 
 			postgres := &postgres.Postgres{
 				DB: db,
@@ -88,12 +150,123 @@ func TestCheckUserByPhoneNumber(t *testing.T) {
 	}
 }
 
+func TestCheckUserByEmail(t *testing.T) {
+	test := []struct {
+		name  string
+		email string
+		err   error
+	}{
+		{
+			name:  "get user",
+			email: "ripper@mail.ru",
+			err:   nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			rows := sqlmock.NewRows([]string{"id", "phone_number", "password", "role"}).
+				AddRow(1, "123", "123", "user")
+			mock.ExpectQuery("SELECT id, phone_number, password, role FROM users").WithArgs(tt.email, model.StatusCreated).WillReturnRows(rows)
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			_, err = postgres.CheckUserByEmail(context.Background(), tt.email)
+			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestGetPasswordById(t *testing.T) {
+	test := []struct {
+		name     string
+		id       uint64
+		password string
+		err      error
+	}{
+		{
+			name:     "get password",
+			id:       1,
+			password: "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5",
+			err:      nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			rows := sqlmock.NewRows([]string{"password"}).AddRow([]byte(tt.password))
+			mock.ExpectQuery("SELECT password FROM users").WithArgs(tt.id, model.StatusCreated).WillReturnRows(rows)
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			password, err := postgres.GetPasswordById(context.Background(), tt.id)
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, password, tt.password)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestUpdatePasswordById(t *testing.T) {
+	test := []struct {
+		name     string
+		id       uint64
+		password string
+		err      error
+	}{
+		{
+			name:     "update password",
+			id:       1,
+			password: "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5",
+			err:      nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectExec("UPDATE users SET password").WithArgs([]byte(tt.password), tt.id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.UpdatePasswordById(context.Background(), tt.id, tt.password)
+			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
 func TestUpdateUserById(t *testing.T) {
 	test := []struct {
-		name string
-		user model.User
-		rows int64
-		err  error
+		name    string
+		user    model.User
+		rows    int64
+		execErr error
+		err     error
 	}{
 		{
 			name: "user exists",
@@ -102,8 +275,9 @@ func TestUpdateUserById(t *testing.T) {
 				PhoneNumber: "+7455456",
 				Email:       "ripper@algsdh",
 			},
-			rows: 1,
-			err:  nil,
+			rows:    1,
+			execErr: nil,
+			err:     nil,
 		},
 		{
 			name: "user does not exist",
@@ -112,8 +286,18 @@ func TestUpdateUserById(t *testing.T) {
 				PhoneNumber: "+7455456",
 				Email:       "ripper@algsdh",
 			},
-			rows: 0,
-			err:  service.ErrUserDoesNotExists,
+			rows:    0,
+			execErr: nil,
+			err:     service.ErrUserDoesNotExists,
+		},
+		{
+			name: "phone number of other user",
+			user: model.User{
+				PhoneNumber: "+7455456",
+			},
+			rows:    0,
+			execErr: &pgconn.PgError{Code: "23505"},
+			err:     service.ErrUserAlreadyExists,
 		},
 	}
 
@@ -124,14 +308,29 @@ func TestUpdateUserById(t *testing.T) {
 				log.Fatalf("sqlmock new failed: %v", err)
 			}
 
-			mock.ExpectExec("UPDATE users").WithArgs(tt.user.Name, tt.user.PhoneNumber, tt.user.Email, "0", model.StatusCreated).WillReturnError(nil).WillReturnResult(sqlmock.NewResult(tt.rows, tt.rows))
+			args := make([]driver.Value, 0, 5)
+			for _, value := range []string{tt.user.Name, tt.user.PhoneNumber, tt.user.Email} {
+				if value == "" {
+					args = append(args, nil)
+					continue
+				}
+				args = append(args, value)
+			}
+			args = append(args, "0", model.StatusCreated)
+
+			exec := mock.ExpectExec("UPDATE users").WithArgs(args...)
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(tt.rows, tt.rows))
+			}
 
 			postgres := &postgres.Postgres{
 				DB: db,
 			}
 
 			err = postgres.UpdateUserById(context.Background(), "0", &tt.user)
-			assert.Equal(t, err, tt.err)
+			assert.Equal(t, errors.Is(err, tt.err), true)
 			err = mock.ExpectationsWereMet()
 			assert.Equal(t, err, nil)
 		})
@@ -210,3 +409,311 @@ func TestDeleteUserById(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUsersByFilter(t *testing.T) {
+	minRating := 4.0
+
+	test := []struct {
+		name   string
+		filter service.UsersFilter
+		query  string
+		args   []driver.Value
+		err    error
+	}{
+		{
+			name:   "no filters",
+			filter: service.UsersFilter{SortBy: service.SortById, Limit: 20},
+			query:  "SELECT id, name, phone_number, email, raiting, status, role FROM users ORDER BY id ASC, id ASC LIMIT $1",
+			args:   []driver.Value{21},
+			err:    nil,
+		},
+		{
+			name: "filters and cursor",
+			filter: service.UsersFilter{
+				Status:    model.StatusDeleted,
+				Name:      "iv_",
+				MinRating: &minRating,
+				SortBy:    service.SortByRating,
+				Desc:      true,
+				Limit:     10,
+				After:     &service.UsersCursor{ID: 5, Rating: 4.5},
+			},
+			query: "SELECT id, name, phone_number, email, raiting, status, role FROM users WHERE status = $1 AND name ILIKE $2 AND raiting >= $3 AND (raiting, id) < ($4, $5) ORDER BY raiting DESC, id DESC LIMIT $6",
+			args:  []driver.Value{model.StatusDeleted, `iv\_%`, 4.0, 4.5, 5, 11},
+			err:   nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			rows := sqlmock.NewRows([]string{"id", "name", "phone_number", "email", "raiting", "status", "role"}).
+				AddRow(1, "Ivan", "123", "123", 4.5, model.StatusDeleted, "user")
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).WillReturnRows(rows)
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			users, err := postgres.GetUsersByFilter(context.Background(), &tt.filter)
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, len(users), 1)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestRestoreUserById(t *testing.T) {
+	test := []struct {
+		name string
+		rows int64
+		err  error
+	}{
+		{
+			name: "restore user",
+			rows: 1,
+			err:  nil,
+		},
+		{
+			name: "user is not deleted",
+			rows: 0,
+			err:  service.ErrUserDoesNotExists,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectExec("UPDATE users SET status").WithArgs(model.StatusCreated, "1", model.StatusDeleted).WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.RestoreUserById(context.Background(), "1")
+			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestAddOrder(t *testing.T) {
+	test := []struct {
+		name  string
+		order model.Order
+		err   error
+	}{
+		{
+			name: "add order",
+			order: model.Order{
+				UserID:   1,
+				DriverID: "1",
+				TaxiType: model.TaxiTypeEconomy,
+				From:     "a",
+				To:       "b",
+				Date:     time.Now(),
+				Status:   model.OrderStatusInProgress,
+			},
+			err: nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+			mock.ExpectQuery("INSERT INTO orders").WithArgs(tt.order.UserID, tt.order.DriverID, tt.order.TaxiType, tt.order.From, tt.order.To, tt.order.Date, tt.order.Status).WillReturnRows(rows)
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.AddOrder(context.Background(), &tt.order)
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, tt.order.ID, uint64(1))
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestGetOrdersByUserId(t *testing.T) {
+	test := []struct {
+		name   string
+		orders int
+		err    error
+	}{
+		{
+			name:   "get orders",
+			orders: 2,
+			err:    nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			rows := sqlmock.NewRows([]string{"id", "user_id", "driver_id", "taxi_type", "from_address", "to_address", "date", "status", "finished_at", "rating"}).
+				AddRow(2, 1, "2", model.TaxiTypeComfort, "b", "c", time.Now(), model.OrderStatusInProgress, nil, 0).
+				AddRow(1, 1, "1", model.TaxiTypeEconomy, "a", "b", time.Now(), model.OrderStatusFinished, time.Now(), 5)
+			mock.ExpectQuery("SELECT id, user_id, driver_id, taxi_type, from_address, to_address, date, status").WithArgs("1").WillReturnRows(rows)
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			orders, err := postgres.GetOrdersByUserId(context.Background(), "1")
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, len(orders), tt.orders)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestSetOrderRating(t *testing.T) {
+	test := []struct {
+		name  string
+		order model.Order
+		rows  int64
+		err   error
+	}{
+		{
+			name: "rate order",
+			order: model.Order{
+				ID:     1,
+				UserID: 1,
+			},
+			rows: 1,
+			err:  nil,
+		},
+		{
+			name: "order already rated",
+			order: model.Order{
+				ID:     1,
+				UserID: 1,
+			},
+			rows: 0,
+			err:  service.ErrOrderAlreadyRated,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE orders SET rating").WithArgs(5, tt.order.ID).WillReturnResult(sqlmock.NewResult(tt.rows, tt.rows))
+			if tt.rows != 0 {
+				mock.ExpectExec("UPDATE users SET raiting").WithArgs(tt.order.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.SetOrderRating(context.Background(), &tt.order, 5)
+			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestHasOrderInProgress(t *testing.T) {
+	test := []struct {
+		name   string
+		exists bool
+		err    error
+	}{
+		{
+			name:   "order in progress",
+			exists: true,
+			err:    nil,
+		},
+		{
+			name:   "no order in progress",
+			exists: false,
+			err:    nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectQuery("SELECT EXISTS").WithArgs("1", "2", model.OrderStatusInProgress).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.exists))
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			exists, err := postgres.HasOrderInProgress(context.Background(), "1", "2")
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, exists, tt.exists)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
+
+func TestFinishOrderByDriverId(t *testing.T) {
+	test := []struct {
+		name     string
+		driverID string
+		err      error
+	}{
+		{
+			name:     "finish order",
+			driverID: "1",
+			err:      nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				log.Fatalf("sqlmock new failed: %v", err)
+			}
+
+			mock.ExpectExec("UPDATE orders").WithArgs(model.OrderStatusFinished, sqlmock.AnyArg(), tt.driverID, model.OrderStatusInProgress).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			postgres := &postgres.Postgres{
+				DB: db,
+			}
+
+			err = postgres.FinishOrderByDriverId(context.Background(), tt.driverID)
+			assert.Equal(t, err, tt.err)
+			err = mock.ExpectationsWereMet()
+			assert.Equal(t, err, nil)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/RipperAcskt/innotaxi/internal/repo/postgres"
+
+// querier is a *sql.DB or a *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// startSpan starts the client span of query, named after its operation. The
+// statement is recorded with the placeholders, never with the arguments.
+func startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := query
+	if i := strings.IndexByte(query, ' '); i > 0 {
+		operation = query[:i]
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, "postgres "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBStatement(query),
+		),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func execContext(ctx context.Context, q querier, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startSpan(ctx, query)
+	res, err := q.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+	return res, err
+}
+
+// queryContext traces the query up to its first row, the rows are read after
+// the span ends.
+func queryContext(ctx context.Context, q querier, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startSpan(ctx, query)
+	rows, err := q.QueryContext(ctx, query, args...)
+	endSpan(span, err)
+	return rows, err
+}
+
+func queryRowContext(ctx context.Context, q querier, query string, args ...interface{}) *sql.Row {
+	ctx, span := startSpan(ctx, query)
+	row := q.QueryRowContext(ctx, query, args...)
+	endSpan(span, row.Err())
+	return row
+}
@@ -1,11 +1,18 @@
 package redis
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/ratelimit"
+	"github.com/RipperAcskt/innotaxi/internal/service"
 	"github.com/go-redis/redis"
+	"github.com/google/uuid"
 )
 
 type Redis struct {
@@ -27,19 +34,367 @@ func New(cfg *config.Config) (*Redis, error) {
 	return &Redis{client, cfg}, nil
 }
 
-func (r *Redis) AddToken(token string, expired time.Duration) error {
-	err := r.client.Set(token, true, expired).Err()
+// PoolStats returns the stats of the connection pool.
+func (r *Redis) PoolStats() *redis.PoolStats {
+	return r.client.PoolStats()
+}
+
+func (r *Redis) AddToken(ctx context.Context, token string, expired time.Duration) error {
+	client := withContext(ctx, r.client)
+	err := client.Set(token, true, expired).Err()
 	if err != nil {
 		return fmt.Errorf("client set failed: %w", err)
 	}
 	return nil
 }
 
-func (r *Redis) GetToken(token string) bool {
-	val := r.client.Get(token).Val()
+func (r *Redis) GetToken(ctx context.Context, token string) bool {
+	client := withContext(ctx, r.client)
+	val := client.Get(token).Val()
 	return val == ""
 }
 
+// rotateRT sets the current token of the family to ARGV[2] if it is ARGV[1]
+// and prolongs the session, otherwise deletes the family and the session.
+var rotateRT = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	redis.call("PEXPIRE", KEYS[2], ARGV[3])
+	return 1
+end
+redis.call("DEL", KEYS[1], KEYS[2])
+return 0
+`)
+
+func rtFamilyKey(family string) string {
+	return "rt:family:" + family
+}
+
+func (r *Redis) AddRTFamily(ctx context.Context, family, id string, expired time.Duration) error {
+	client := withContext(ctx, r.client)
+	err := client.Set(rtFamilyKey(family), id, expired).Err()
+	if err != nil {
+		return fmt.Errorf("client set failed: %w", err)
+	}
+	return nil
+}
+
+func (r *Redis) RotateRT(ctx context.Context, family, oldID, newID string, expired time.Duration) (bool, error) {
+	client := withContext(ctx, r.client)
+	res, err := rotateRT.Run(client, []string{rtFamilyKey(family), sessionKey(family)}, oldID, newID, expired.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("run failed: %w", err)
+	}
+	return res == 1, nil
+}
+
+func (r *Redis) DeleteRTFamily(ctx context.Context, family string) error {
+	client := withContext(ctx, r.client)
+	err := client.Del(rtFamilyKey(family)).Err()
+	if err != nil {
+		return fmt.Errorf("client del failed: %w", err)
+	}
+	return nil
+}
+
+// A session is stored under its id, which is the family of its refresh
+// tokens, and expires with them. The set of the user's session ids is cleaned
+// up lazily when the sessions are listed.
+func sessionKey(id string) string {
+	return "session:" + id
+}
+
+func userSessionsKey(userID string) string {
+	return "sessions:" + userID
+}
+
+func (r *Redis) AddSession(ctx context.Context, session *model.Session, expired time.Duration) error {
+	client := withContext(ctx, r.client)
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshal failed: %w", err)
+	}
+
+	_, err = client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Set(sessionKey(session.ID), data, expired)
+		pipe.SAdd(userSessionsKey(fmt.Sprint(session.UserID)), session.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tx pipelined failed: %w", err)
+	}
+	return nil
+}
+
+func (r *Redis) CheckSession(ctx context.Context, id string) (bool, error) {
+	client := withContext(ctx, r.client)
+	n, err := client.Exists(sessionKey(id)).Result()
+	if err != nil {
+		return false, fmt.Errorf("client exists failed: %w", err)
+	}
+	return n == 1, nil
+}
+
+func (r *Redis) GetSessionsByUserId(ctx context.Context, userID string) ([]*model.Session, error) {
+	client := withContext(ctx, r.client)
+	ids, err := client.SMembers(userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("client smembers failed: %w", err)
+	}
+
+	sessions := make([]*model.Session, 0, len(ids))
+	for _, id := range ids {
+		data, err := client.Get(sessionKey(id)).Bytes()
+		if err == redis.Nil {
+			err = client.SRem(userSessionsKey(userID), id).Err()
+			if err != nil {
+				return nil, fmt.Errorf("client srem failed: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("client get failed: %w", err)
+		}
+
+		var session model.Session
+		err = json.Unmarshal(data, &session)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal failed: %w", err)
+		}
+		sessions = append(sessions, &session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].IssuedAt.After(sessions[j].IssuedAt)
+	})
+	return sessions, nil
+}
+
+func (r *Redis) DeleteSessionById(ctx context.Context, userID, id string) error {
+	client := withContext(ctx, r.client)
+	ok, err := client.SIsMember(userSessionsKey(userID), id).Result()
+	if err != nil {
+		return fmt.Errorf("client sismember failed: %w", err)
+	}
+	if !ok {
+		return service.ErrSessionNotFound
+	}
+
+	_, err = client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Del(sessionKey(id), rtFamilyKey(id))
+		pipe.SRem(userSessionsKey(userID), id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tx pipelined failed: %w", err)
+	}
+	return nil
+}
+
+func (r *Redis) DeleteSessionsByUserId(ctx context.Context, userID string) error {
+	client := withContext(ctx, r.client)
+	ids, err := client.SMembers(userSessionsKey(userID)).Result()
+	if err != nil {
+		return fmt.Errorf("client smembers failed: %w", err)
+	}
+
+	keys := make([]string, 0, 2*len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, sessionKey(id), rtFamilyKey(id))
+	}
+	keys = append(keys, userSessionsKey(userID))
+
+	err = client.Del(keys...).Err()
+	if err != nil {
+		return fmt.Errorf("client del failed: %w", err)
+	}
+	return nil
+}
+
+// checkOTP counts the attempt and compares the code with ARGV[1]. It returns
+// the value of the code if it matches, 0 if it doesn't, -1 if there is no
+// code and -2 if the attempts of ARGV[2] are used up.
+var checkOTP = redis.NewScript(`
+local code = redis.call("HGET", KEYS[1], "code")
+if not code then
+	return -1
+end
+if code == ARGV[1] then
+	local value = redis.call("HGET", KEYS[1], "value")
+	redis.call("DEL", KEYS[1])
+	return value
+end
+if redis.call("HINCRBY", KEYS[1], "attempts", 1) >= tonumber(ARGV[2]) then
+	redis.call("DEL", KEYS[1])
+	return -2
+end
+return 0
+`)
+
+func otpKey(key string) string {
+	return "otp:" + key
+}
+
+func (r *Redis) AddOTP(ctx context.Context, key, code, value string, expired time.Duration) error {
+	client := withContext(ctx, r.client)
+	_, err := client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Del(otpKey(key))
+		pipe.HMSet(otpKey(key), map[string]interface{}{"code": code, "value": value})
+		pipe.Expire(otpKey(key), expired)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tx pipelined failed: %w", err)
+	}
+	return nil
+}
+
+func (r *Redis) CheckOTP(ctx context.Context, key, code string, attempts int) (string, bool, error) {
+	client := withContext(ctx, r.client)
+	res, err := checkOTP.Run(client, []string{otpKey(key)}, code, attempts).Result()
+	if err != nil {
+		return "", false, fmt.Errorf("run failed: %w", err)
+	}
+
+	switch res := res.(type) {
+	case string:
+		return res, true, nil
+	case int64:
+		switch res {
+		case -1:
+			return "", false, service.ErrOTPNotFound
+		case -2:
+			return "", false, service.ErrTooManyOTPTries
+		}
+		return "", false, nil
+	}
+	return "", false, fmt.Errorf("unexpected result: %v", res)
+}
+
+// addFailedLogin drops the failures of KEYS[1] older than the window of
+// ARGV[2] milliseconds before ARGV[1], adds the failure ARGV[3] at ARGV[1] and
+// returns the number of failures in the window.
+var addFailedLogin = redis.NewScript(`
+local now = tonumber(ARGV[1])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - tonumber(ARGV[2]))
+redis.call("ZADD", KEYS[1], now, ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+return redis.call("ZCARD", KEYS[1])
+`)
+
+func failedLoginsKey(key string) string {
+	return "login:failed:" + key
+}
+
+func loginLockKey(key string) string {
+	return "login:lock:" + key
+}
+
+func (r *Redis) AddFailedLogin(ctx context.Context, key string, window time.Duration) (int, error) {
+	client := withContext(ctx, r.client)
+	now := time.Now().UnixMilli()
+	res, err := addFailedLogin.Run(client, []string{failedLoginsKey(key)}, now, window.Milliseconds(), uuid.NewString()).Int()
+	if err != nil {
+		return 0, fmt.Errorf("run failed: %w", err)
+	}
+	return res, nil
+}
+
+func (r *Redis) DeleteFailedLogins(ctx context.Context, key string) error {
+	client := withContext(ctx, r.client)
+	err := client.Del(failedLoginsKey(key)).Err()
+	if err != nil {
+		return fmt.Errorf("client del failed: %w", err)
+	}
+	return nil
+}
+
+func (r *Redis) LockLogin(ctx context.Context, key string, expired time.Duration) error {
+	client := withContext(ctx, r.client)
+	err := client.Set(loginLockKey(key), true, expired).Err()
+	if err != nil {
+		return fmt.Errorf("client set failed: %w", err)
+	}
+	return nil
+}
+
+// GetLoginLock returns 0 if there is no lock, PTTL is negative then.
+func (r *Redis) GetLoginLock(ctx context.Context, key string) (time.Duration, error) {
+	client := withContext(ctx, r.client)
+	ttl, err := client.PTTL(loginLockKey(key)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("client pttl failed: %w", err)
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// takeToken refills the bucket of KEYS[1] with one token per ARGV[3]
+// microseconds up to ARGV[2] tokens since it was updated, takes a token at
+// ARGV[1] if there is one and returns whether it did, the tokens left and the
+// microseconds until the bucket is full and until the next token.
+var takeToken = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local interval = math.max(1, tonumber(ARGV[3]))
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(bucket[1]) or burst
+local updated = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - updated) / interval)
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * interval)
+end
+local reset = math.ceil((burst - tokens) * interval)
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "updated", now)
+redis.call("PEXPIRE", KEYS[1], math.max(math.ceil(reset / 1000), 1))
+return {allowed, math.floor(tokens), reset, retry}
+`)
+
+func rateLimitKey(key string) string {
+	return "ratelimit:" + key
+}
+
+// Take takes a request from the bucket of key, the buckets are shared by
+// every instance of the service.
+func (r *Redis) Take(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Result, error) {
+	client := withContext(ctx, r.client)
+	// Microseconds, as a rate over 60000 per minute would refill a token
+	// every 0 milliseconds.
+	now := time.Now().UnixMicro()
+	interval := (time.Minute / time.Duration(limit.Rate)).Microseconds()
+	res, err := takeToken.Run(client, []string{rateLimitKey(key)}, now, limit.Burst, interval).Result()
+	if err != nil {
+		return nil, fmt.Errorf("run failed: %w", err)
+	}
+
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 4 {
+		return nil, fmt.Errorf("unexpected result: %v", res)
+	}
+	ints := make([]int64, len(values))
+	for i, value := range values {
+		ints[i], ok = value.(int64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected result: %v", res)
+		}
+	}
+
+	return &ratelimit.Result{
+		Allowed:    ints[0] == 1,
+		Limit:      limit.Burst,
+		Remaining:  int(ints[1]),
+		Reset:      time.Duration(ints[2]) * time.Microsecond,
+		RetryAfter: time.Duration(ints[3]) * time.Microsecond,
+	}, nil
+}
+
 func (r *Redis) Close() error {
 	return r.client.Close()
 }
@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/RipperAcskt/innotaxi/internal/repo/redis"
+
+// withContext returns a copy of the client bound to ctx which starts a client
+// span for every command and pipeline as a child of the span of ctx. The
+// arguments aren't recorded, the keys hold tokens.
+func withContext(ctx context.Context, client *redis.Client) *redis.Client {
+	tracer := otel.Tracer(tracerName)
+	client = client.WithContext(ctx)
+
+	client.WrapProcess(func(process func(cmd redis.Cmder) error) func(cmd redis.Cmder) error {
+		return func(cmd redis.Cmder) error {
+			span := startSpan(ctx, tracer, "redis "+cmd.Name(), cmd.Name())
+			err := process(cmd)
+			endSpan(span, err)
+			return err
+		}
+	})
+
+	client.WrapProcessPipeline(func(process func(cmds []redis.Cmder) error) func(cmds []redis.Cmder) error {
+		return func(cmds []redis.Cmder) error {
+			names := make([]string, 0, len(cmds))
+			for _, cmd := range cmds {
+				names = append(names, cmd.Name())
+			}
+
+			span := startSpan(ctx, tracer, "redis pipeline", strings.Join(names, " "))
+			err := process(cmds)
+			endSpan(span, err)
+			return err
+		}
+	})
+	return client
+}
+
+func startSpan(ctx context.Context, tracer trace.Tracer, name, operation string) trace.Span {
+	_, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperation(operation),
+		),
+	)
+	return span
+}
+
+// endSpan ends the span, a missing key isn't an error.
+func endSpan(span trace.Span, err error) {
+	if err != nil && err != redis.Nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
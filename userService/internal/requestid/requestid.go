@@ -0,0 +1,55 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Header is the HTTP header of the request ID.
+	Header = "X-Request-ID"
+	// MetadataKey is the gRPC metadata key of the request ID.
+	MetadataKey = "x-request-id"
+
+	maxLen = 128
+)
+
+type key struct{}
+
+// New returns a random request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether id taken from a client can be used as a request ID:
+// up to 128 printable ASCII characters, so it can't break the log lines.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns id if it's Valid, a new request ID if not.
+func Get(id string) string {
+	if Valid(id) {
+		return id
+	}
+	return New()
+}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// FromContext returns the request ID of ctx, "" if it has none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(key{}).(string)
+	return id
+}
@@ -0,0 +1,56 @@
+package requestid_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi/internal/requestid"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestGet(t *testing.T) {
+	test := []struct {
+		name string
+		id   string
+		keep bool
+	}{
+		{
+			name: "client id",
+			id:   "9f1c2a7e-request",
+			keep: true,
+		},
+		{
+			name: "empty",
+			id:   "",
+			keep: false,
+		},
+		{
+			name: "too long",
+			id:   strings.Repeat("a", 129),
+			keep: false,
+		},
+		{
+			name: "control characters",
+			id:   "id\n{\"level\":\"error\"}",
+			keep: false,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			id := requestid.Get(tt.id)
+
+			assert.Equal(t, id == tt.id, tt.keep)
+			assert.Equal(t, requestid.Valid(id), true)
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, requestid.FromContext(ctx), "")
+
+	ctx = requestid.NewContext(ctx, "request")
+	assert.Equal(t, requestid.FromContext(ctx), "request")
+}
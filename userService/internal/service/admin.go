@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+)
+
+const (
+	SortById     = "id"
+	SortByName   = "name"
+	SortByRating = "rating"
+
+	defaultUsersLimit = 20
+	maxUsersLimit     = 100
+)
+
+var ErrBadFilter = NewError(CodeInvalidArgument, "bad filter")
+
+// UsersFilter selects the users of the admin list. Name and email are
+// matched by case-insensitive prefix, phone number by prefix. Sort is one of
+// id, name or rating, prefixed with "-" for descending order.
+type UsersFilter struct {
+	Status    string   `form:"status" binding:"omitempty,oneof=pending created deleted"`
+	Name      string   `form:"name"`
+	Phone     string   `form:"phone"`
+	Email     string   `form:"email"`
+	MinRating *float64 `form:"min_rating"`
+	MaxRating *float64 `form:"max_rating"`
+	Sort      string   `form:"sort"`
+	Limit     int      `form:"limit" binding:"min=0"`
+	Cursor    string   `form:"cursor"`
+
+	// SortBy, Desc and After are set from Sort and Cursor by the service.
+	SortBy string       `form:"-"`
+	Desc   bool         `form:"-"`
+	After  *UsersCursor `form:"-"`
+}
+
+// UsersCursor is the last user of the previous page, the next page starts
+// after it in the sort order.
+type UsersCursor struct {
+	ID     uint64  `json:"id"`
+	Name   string  `json:"name,omitempty"`
+	Rating float64 `json:"rating,omitempty"`
+}
+
+type UsersPage struct {
+	Users      []*model.UserRecord `json:"users"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+type AdminRepo interface {
+	// GetUsersByFilter returns up to one user more than the filter's limit,
+	// so the caller knows whether there is a next page.
+	GetUsersByFilter(ctx context.Context, filter *UsersFilter) ([]*model.UserRecord, error)
+	RestoreUserById(ctx context.Context, id string) error
+}
+
+type AdminService struct {
+	AdminRepo
+}
+
+func NewAdminService(postgres AdminRepo) *AdminService {
+	return &AdminService{postgres}
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, filter *UsersFilter) (*UsersPage, error) {
+	err := normalizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.GetUsersByFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get users by filter failed: %w", err)
+	}
+
+	page := &UsersPage{Users: users}
+	if len(users) > filter.Limit {
+		page.Users = users[:filter.Limit]
+
+		last := page.Users[len(page.Users)-1]
+		page.NextCursor, err = encodeCursor(&UsersCursor{last.ID, last.Name, last.Raiting})
+		if err != nil {
+			return nil, fmt.Errorf("encode cursor failed: %w", err)
+		}
+	}
+	return page, nil
+}
+
+// RestoreUser undoes the soft delete of the user.
+func (s *AdminService) RestoreUser(ctx context.Context, id string) error {
+	return s.RestoreUserById(ctx, id)
+}
+
+func normalizeFilter(filter *UsersFilter) error {
+	switch filter.Status {
+	case "", model.StatusPending, model.StatusCreated, model.StatusDeleted:
+	default:
+		return fmt.Errorf("status %v: %w", filter.Status, ErrBadFilter)
+	}
+	if filter.MinRating != nil && filter.MaxRating != nil && *filter.MinRating > *filter.MaxRating {
+		return fmt.Errorf("min rating is greater than max rating: %w", ErrBadFilter)
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultUsersLimit
+	case filter.Limit < 0 || filter.Limit > maxUsersLimit:
+		return fmt.Errorf("limit must be from 1 to %v: %w", maxUsersLimit, ErrBadFilter)
+	}
+
+	filter.Desc = strings.HasPrefix(filter.Sort, "-")
+	filter.SortBy = strings.TrimPrefix(filter.Sort, "-")
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = SortById
+	case SortById, SortByName, SortByRating:
+	default:
+		return fmt.Errorf("sort %v: %w", filter.Sort, ErrBadFilter)
+	}
+
+	if filter.Cursor != "" {
+		var after UsersCursor
+		err := decodeCursor(filter.Cursor, &after)
+		if err != nil {
+			return fmt.Errorf("cursor: %v: %w", err, ErrBadFilter)
+		}
+		filter.After = &after
+	}
+	return nil
+}
+
+func encodeCursor(cursor interface{}) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("marshal failed: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor string, after interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("decode string failed: %w", err)
+	}
+
+	err = json.Unmarshal(data, after)
+	if err != nil {
+		return fmt.Errorf("unmarshal failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/RipperAcskt/innotaxi/internal/service/mocks"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+)
+
+func TestListUsers(t *testing.T) {
+	type mockBehavior func(s *mocks.MockAdminRepo)
+
+	users := []*model.UserRecord{
+		{ID: 3, Name: "Anna", Raiting: 4.5},
+		{ID: 1, Name: "Ivan", Raiting: 4.5},
+		{ID: 2, Name: "Oleg", Raiting: 3},
+	}
+	minRating, maxRating := 4.0, 3.0
+
+	test := []struct {
+		name         string
+		filter       service.UsersFilter
+		mockBehavior mockBehavior
+		users        int
+		next         bool
+		err          error
+	}{
+		{
+			name:   "first page",
+			filter: service.UsersFilter{Sort: "-rating", Limit: 2},
+			mockBehavior: func(s *mocks.MockAdminRepo) {
+				s.EXPECT().GetUsersByFilter(context.Background(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *service.UsersFilter) ([]*model.UserRecord, error) {
+					assert.Equal(t, filter.SortBy, service.SortByRating)
+					assert.Equal(t, filter.Desc, true)
+					return users, nil
+				})
+			},
+			users: 2,
+			next:  true,
+			err:   nil,
+		},
+		{
+			name:   "last page",
+			filter: service.UsersFilter{Sort: "-rating", Cursor: "eyJpZCI6MSwibmFtZSI6Ikl2YW4iLCJyYXRpbmciOjQuNX0"},
+			mockBehavior: func(s *mocks.MockAdminRepo) {
+				s.EXPECT().GetUsersByFilter(context.Background(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *service.UsersFilter) ([]*model.UserRecord, error) {
+					assert.Equal(t, filter.Limit, 20)
+					assert.Equal(t, filter.After, &service.UsersCursor{ID: 1, Name: "Ivan", Rating: 4.5})
+					return users[2:], nil
+				})
+			},
+			users: 1,
+			next:  false,
+			err:   nil,
+		},
+		{
+			name:   "pending users",
+			filter: service.UsersFilter{Status: model.StatusPending},
+			mockBehavior: func(s *mocks.MockAdminRepo) {
+				s.EXPECT().GetUsersByFilter(context.Background(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *service.UsersFilter) ([]*model.UserRecord, error) {
+					assert.Equal(t, filter.Status, model.StatusPending)
+					return users[:1], nil
+				})
+			},
+			users: 1,
+			next:  false,
+			err:   nil,
+		},
+		{
+			name:         "unknown status",
+			filter:       service.UsersFilter{Status: "banned"},
+			mockBehavior: func(s *mocks.MockAdminRepo) {},
+			err:          service.ErrBadFilter,
+		},
+		{
+			name:         "unknown sort",
+			filter:       service.UsersFilter{Sort: "password"},
+			mockBehavior: func(s *mocks.MockAdminRepo) {},
+			err:          service.ErrBadFilter,
+		},
+		{
+			name:         "bad cursor",
+			filter:       service.UsersFilter{Cursor: "!"},
+			mockBehavior: func(s *mocks.MockAdminRepo) {},
+			err:          service.ErrBadFilter,
+		},
+		{
+			name:         "min rating greater than max",
+			filter:       service.UsersFilter{MinRating: &minRating, MaxRating: &maxRating},
+			mockBehavior: func(s *mocks.MockAdminRepo) {},
+			err:          service.ErrBadFilter,
+		},
+		{
+			name:         "limit too big",
+			filter:       service.UsersFilter{Limit: 1000},
+			mockBehavior: func(s *mocks.MockAdminRepo) {},
+			err:          service.ErrBadFilter,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adminRepo := mocks.NewMockAdminRepo(ctrl)
+			adminService := service.NewAdminService(adminRepo)
+
+			tt.mockBehavior(adminRepo)
+
+			service := service.Service{
+				AdminService: adminService,
+			}
+
+			page, err := service.ListUsers(context.Background(), &tt.filter)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			if tt.err == nil {
+				assert.Equal(t, len(page.Users), tt.users)
+				assert.Equal(t, page.NextCursor != "", tt.next)
+			}
+		})
+	}
+}
+
+func TestRestoreUser(t *testing.T) {
+	type mockBehavior func(s *mocks.MockAdminRepo)
+	test := []struct {
+		name         string
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name: "restore user",
+			mockBehavior: func(s *mocks.MockAdminRepo) {
+				s.EXPECT().RestoreUserById(context.Background(), "1").Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name: "user is not deleted",
+			mockBehavior: func(s *mocks.MockAdminRepo) {
+				s.EXPECT().RestoreUserById(context.Background(), "1").Return(service.ErrUserDoesNotExists)
+			},
+			err: service.ErrUserDoesNotExists,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			adminRepo := mocks.NewMockAdminRepo(ctrl)
+			adminService := service.NewAdminService(adminRepo)
+
+			tt.mockBehavior(adminRepo)
+
+			service := service.Service{
+				AdminService: adminService,
+			}
+
+			err := service.RestoreUser(context.Background(), "1")
+			assert.Equal(t, err, tt.err)
+		})
+	}
+}
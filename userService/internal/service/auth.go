@@ -3,52 +3,85 @@ package service
 import (
 	"context"
 	"crypto/sha1"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/model"
 )
 
 var (
-	ErrUserAlreadyExists = fmt.Errorf("user already exists")
-	ErrUserDoesNotExists = fmt.Errorf("user does not exists")
-	ErrIncorrectPassword = fmt.Errorf("incorrect password")
+	ErrUserAlreadyExists = NewError(CodeAlreadyExists, "user already exists")
+	ErrUserDoesNotExists = NewError(CodeNotFound, "user does not exists")
+	ErrIncorrectPassword = NewError(CodePermissionDenied, "incorrect password")
+	ErrRTReused          = NewError(CodeUnauthenticated, "refresh token reused")
+	ErrSessionRevoked    = NewError(CodeUnauthenticated, "session revoked")
 )
 
 type UserSingUp struct {
-	Name        string `json:"name" binding:"required"`
-	PhoneNumber string `json:"phone_number" binding:"required"`
-	Email       string `json:"email" binding:"required"`
-	Password    string `json:"password" binding:"required"`
+	Name        string `json:"name" binding:"required,name"`
+	PhoneNumber string `json:"phone_number" binding:"required,phone"`
+	Email       string `json:"email" binding:"required,mail"`
+	Password    string `json:"password" binding:"required,password"`
 }
 
 type UserSingIn struct {
 	ID          uint64 `json:"-"`
 	PhoneNumber string `json:"phone_number" binding:"required"`
 	Password    string `json:"password" binding:"required"`
+	Role        string `json:"-"`
+	Device      string `json:"device" binding:"max=100"`
+	IP          string `json:"-"`
+	UserAgent   string `json:"-"`
 }
 
 type AuthRepo interface {
 	CreateUser(ctx context.Context, user UserSingUp) error
 	CheckUserByPhoneNumber(ctx context.Context, phone string) (*UserSingIn, error)
+	CheckUserByEmail(ctx context.Context, email string) (*UserSingIn, error)
+	GetPasswordById(ctx context.Context, id uint64) (string, error)
+	UpdatePasswordById(ctx context.Context, id uint64, password string) error
+	ActivateUserByPhoneNumber(ctx context.Context, phone string) error
 }
 
 type TokenRepo interface {
-	AddToken(token string, expired time.Duration) error
-	GetToken(token string) bool
+	AddToken(ctx context.Context, token string, expired time.Duration) error
+	GetToken(ctx context.Context, token string) bool
+	// AddRTFamily starts a family of refresh tokens whose current token is id.
+	AddRTFamily(ctx context.Context, family, id string, expired time.Duration) error
+	// RotateRT replaces the current token of the family with newID if it is
+	// oldID. Otherwise the family is deleted and false is returned.
+	RotateRT(ctx context.Context, family, oldID, newID string, expired time.Duration) (bool, error)
+	DeleteRTFamily(ctx context.Context, family string) error
+	SessionRepo
+	OTPRepo
+	LoginRepo
 }
 type AuthService struct {
 	AuthRepo
 	TokenRepo
-	salt string
-	cfg  *config.Config
+	sms    SmsSender
+	mailer Mailer
+	otp    otp
+	hasher PasswordHasher
+	keys   *KeySet
+	salt   string
+	cfg    *config.Config
 }
 
-func NewAuthSevice(postgres AuthRepo, redis TokenRepo, salt string, cfg *config.Config) *AuthService {
-	return &AuthService{postgres, redis, salt, cfg}
+func NewAuthSevice(postgres AuthRepo, redis TokenRepo, sms SmsSender, mailer Mailer, keys *KeySet, salt string, cfg *config.Config) *AuthService {
+	return &AuthService{postgres, redis, sms, mailer, otp{redis, cfg}, NewPasswordHasher(cfg.PASSWORD_HASHER), keys, salt, cfg}
 }
 
+// SingUp creates a pending user and sends a code to the phone number. The
+// user can sign in once the number is verified with the code.
 func (s *AuthService) SingUp(ctx context.Context, user UserSingUp) error {
+	user.Name = NormalizeName(user.Name)
+	user.PhoneNumber = NormalizePhone(user.PhoneNumber)
+	user.Email = NormalizeEmail(user.Email)
+
 	var err error
 	user.Password, err = s.GenerateHash(user.Password)
 	if err != nil {
@@ -59,38 +92,89 @@ func (s *AuthService) SingUp(ctx context.Context, user UserSingUp) error {
 	if err != nil {
 		return err
 	}
+
+	err = s.SendOTP(ctx, user.PhoneNumber)
+	if err != nil {
+		return fmt.Errorf("send otp failed: %w", err)
+	}
 	return nil
 }
 
 func (s *AuthService) GenerateHash(password string) (string, error) {
-	hash := sha1.New()
-	_, err := hash.Write([]byte(password))
+	return s.hasher.Hash(password)
+}
+
+// ComparePassword reports whether password matches the stored hash and
+// whether the hash should be replaced with a new one. Hashes made before
+// PasswordHasher was introduced are salted SHA-1 and always need a rehash.
+func (s *AuthService) ComparePassword(hash, password string) (ok bool, rehash bool, err error) {
+	if hasher := hasherOf(hash); hasher != nil {
+		ok, err = hasher.Compare(hash, password)
+		if err != nil {
+			return false, false, fmt.Errorf("compare failed: %w", err)
+		}
+		return ok, ok && s.hasher.NeedsRehash(hash), nil
+	}
+
+	legacy := sha1.New()
+	_, err = legacy.Write([]byte(password))
 	if err != nil {
-		return "", fmt.Errorf("write failed: %w", err)
+		return false, false, fmt.Errorf("write failed: %w", err)
 	}
-	return string(hash.Sum([]byte(s.salt))), nil
+	ok = subtle.ConstantTimeCompare([]byte(hash), legacy.Sum([]byte(s.salt))) == 1
+	return ok, ok, nil
 }
 
+// SingIn checks the password and opens a session. Failed sign ins are
+// counted for the phone number and the IP, and LockedError is returned while
+// one of them is locked.
 func (s *AuthService) SingIn(ctx context.Context, user UserSingIn) (*Token, error) {
+	user.PhoneNumber = NormalizePhone(user.PhoneNumber)
+
+	err := s.checkLoginLock(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
 	userDB, err := s.CheckUserByPhoneNumber(ctx, user.PhoneNumber)
 	if err != nil {
+		// An unknown number fails like a wrong password, so sign in doesn't
+		// tell who has an account.
+		if errors.Is(err, ErrUserDoesNotExists) {
+			return nil, s.failLogin(ctx, user, ErrIncorrectPassword)
+		}
 		return nil, fmt.Errorf("check user by phone number failed: %w", err)
 	}
 
-	hash := sha1.New()
-	_, err = hash.Write([]byte(user.Password))
+	ok, rehash, err := s.ComparePassword(userDB.Password, user.Password)
 	if err != nil {
-		return nil, fmt.Errorf("write failed: %w", err)
+		return nil, fmt.Errorf("compare password failed: %w", err)
+	}
+	if !ok {
+		return nil, s.failLogin(ctx, user, ErrIncorrectPassword)
 	}
 
-	if userDB.Password != string(hash.Sum([]byte(s.salt))) {
-		return nil, ErrIncorrectPassword
+	err = s.DeleteFailedLogins(ctx, phoneLoginKey(user.PhoneNumber))
+	if err != nil {
+		return nil, fmt.Errorf("delete failed logins failed: %w", err)
+	}
+
+	if rehash {
+		hash, err := s.GenerateHash(user.Password)
+		if err != nil {
+			return nil, fmt.Errorf("generate hash failed: %w", err)
+		}
+
+		err = s.UpdatePasswordById(ctx, userDB.ID, hash)
+		if err != nil {
+			return nil, fmt.Errorf("update password by id failed: %w", err)
+		}
 	}
 
 	params := TokenParams{
 		ID:                userDB.ID,
-		Type:              User,
-		HS256_SECRET:      s.cfg.HS256_SECRET,
+		Type:              userDB.Role,
+		Keys:              s.keys,
 		ACCESS_TOKEN_EXP:  s.cfg.ACCESS_TOKEN_EXP,
 		REFRESH_TOKEN_EXP: s.cfg.REFRESH_TOKEN_EXP,
 	}
@@ -100,13 +184,101 @@ func (s *AuthService) SingIn(ctx context.Context, user UserSingIn) (*Token, erro
 		return nil, fmt.Errorf("new token failed: %w", err)
 	}
 
+	err = s.AddRTFamily(ctx, token.Family, token.RTID, time.Until(token.RTExpiration))
+	if err != nil {
+		return nil, fmt.Errorf("add rt family failed: %w", err)
+	}
+
+	session := &model.Session{
+		ID:        token.Family,
+		UserID:    userDB.ID,
+		Device:    user.Device,
+		IP:        user.IP,
+		UserAgent: user.UserAgent,
+		IssuedAt:  time.Now().UTC(),
+	}
+	err = s.AddSession(ctx, session, time.Until(token.RTExpiration))
+	if err != nil {
+		return nil, fmt.Errorf("add session failed: %w", err)
+	}
+
 	return token, nil
 }
 
-func (s *AuthService) Logout(userId string, token string, expired time.Duration) error {
-	return s.AddToken(token, expired)
+// Refresh exchanges the refresh token for a new token pair. Every refresh
+// token can be used only once: using it again revokes all the tokens
+// rotated from the same sign in, so a stolen token is of no use after the
+// owner or the thief has used it.
+func (s *AuthService) Refresh(ctx context.Context, refresh string) (*Token, error) {
+	claims, err := VerifyRefresh(refresh, s.keys)
+	if err != nil {
+		return nil, fmt.Errorf("verify refresh failed: %w", err)
+	}
+
+	params := TokenParams{
+		ID:                claims.UserID,
+		Type:              claims.Role,
+		Keys:              s.keys,
+		ACCESS_TOKEN_EXP:  s.cfg.ACCESS_TOKEN_EXP,
+		REFRESH_TOKEN_EXP: s.cfg.REFRESH_TOKEN_EXP,
+		Family:            claims.Family,
+	}
+
+	ok, err := s.CheckSession(ctx, claims.Family)
+	if err != nil {
+		return nil, fmt.Errorf("check session failed: %w", err)
+	}
+	if !ok {
+		return nil, ErrSessionRevoked
+	}
+
+	token, err := NewToken(params)
+	if err != nil {
+		return nil, fmt.Errorf("new token failed: %w", err)
+	}
+
+	ok, err = s.RotateRT(ctx, claims.Family, claims.ID, token.RTID, time.Until(token.RTExpiration))
+	if err != nil {
+		return nil, fmt.Errorf("rotate rt failed: %w", err)
+	}
+	if !ok {
+		return nil, ErrRTReused
+	}
+
+	return token, nil
+}
+
+// VerifyAccess verifies the access token and checks that its session
+// hasn't been revoked. Drivers sign in to the driver service, so their tokens
+// have no session here.
+func (s *AuthService) VerifyAccess(ctx context.Context, token string) (*AccessClaims, error) {
+	claims, err := VerifyAccess(token, s.keys)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Role == Driver {
+		return claims, nil
+	}
+
+	ok, err := s.CheckSession(ctx, claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("check session failed: %w", err)
+	}
+	if !ok {
+		return nil, ErrSessionRevoked
+	}
+	return claims, nil
+}
+
+// JWKS returns the public keys tokens can be verified with.
+func (s *AuthService) JWKS() *JWKS {
+	return s.keys.JWKS()
+}
+
+func (s *AuthService) Logout(ctx context.Context, userId string, token string, expired time.Duration) error {
+	return s.AddToken(ctx, token, expired)
 }
 
-func (s *AuthService) CheckToken(userId string) bool {
-	return s.GetToken(userId)
+func (s *AuthService) CheckToken(ctx context.Context, userId string) bool {
+	return s.GetToken(ctx, userId)
 }
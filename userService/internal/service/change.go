@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+)
+
+// Fields of the profile whose changes must be confirmed.
+const (
+	FieldEmail = "email"
+	FieldPhone = "phone_number"
+)
+
+type ChangeConfirm struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// Mailer sends emails.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+func changeKey(id, field string) string {
+	return "change:" + field + ":" + id
+}
+
+// requestChange holds the new value of the field until the code sent to it
+// is confirmed, so nobody can take an email or a phone number they don't own.
+func (s *UserService) requestChange(ctx context.Context, id, field, value string) error {
+	code, err := s.otp.issue(ctx, changeKey(id, field), value)
+	if err != nil {
+		return fmt.Errorf("issue failed: %w", err)
+	}
+
+	text := fmt.Sprintf("InnoTaxi code: %s. It expires in %d minutes.", code, s.otp.exp())
+	switch field {
+	case FieldEmail:
+		err = s.mailer.Send(ctx, value, "Confirm your email", text)
+	case FieldPhone:
+		err = s.sms.Send(ctx, value, text)
+	}
+	if err != nil {
+		return fmt.Errorf("send failed: %w", err)
+	}
+	return nil
+}
+
+// ConfirmChange checks the code sent to the new value of the field and
+// stores the value.
+func (s *UserService) ConfirmChange(ctx context.Context, id, field, code string) error {
+	value, err := s.otp.check(ctx, changeKey(id, field), code)
+	if err != nil {
+		return fmt.Errorf("check failed: %w", err)
+	}
+
+	update := &model.User{}
+	switch field {
+	case FieldEmail:
+		update.Email = value
+	case FieldPhone:
+		update.PhoneNumber = value
+	}
+
+	err = s.UpdateUserById(ctx, id, update)
+	if err != nil {
+		return fmt.Errorf("update user by id failed: %w", err)
+	}
+	return nil
+}
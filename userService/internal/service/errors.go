@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+)
+
+// Code is the kind of an Error, the transports map it to their statuses.
+type Code string
+
+const (
+	CodeInvalidArgument    Code = "invalid_argument"
+	CodeValidation         Code = "validation_failed"
+	CodeUnauthenticated    Code = "unauthenticated"
+	CodePermissionDenied   Code = "permission_denied"
+	CodeNotFound           Code = "not_found"
+	CodeAlreadyExists      Code = "already_exists"
+	CodeFailedPrecondition Code = "failed_precondition"
+	CodeResourceExhausted  Code = "resource_exhausted"
+	CodeInternal           Code = "internal"
+)
+
+// Error is an error clients are told about. Message and Details are sent to
+// them, so they must not hold anything internal. Errors of the service are
+// wrapped with context with fmt.Errorf, the Error of the chain is what the
+// client gets, every other error is reported as internal.
+type Error struct {
+	Code    Code
+	Message string
+	Details map[string]interface{}
+}
+
+func NewError(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// WithDetails returns a copy of e with details, the copy still matches e
+// with errors.Is.
+func (e *Error) WithDetails(details map[string]interface{}) *Error {
+	return &Error{Code: e.Code, Message: e.Message, Details: details}
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == e.Message
+}
+
+var (
+	ErrInternal         = NewError(CodeInternal, "internal error")
+	ErrValidation       = NewError(CodeValidation, "validation failed")
+	ErrMalformedRequest = NewError(CodeInvalidArgument, "malformed request")
+	ErrRateLimited      = NewError(CodeResourceExhausted, "rate limit exceeded")
+)
+
+// AsError returns the Error of err's chain, ErrInternal if there is none.
+func AsError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return ErrInternal
+}
@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestAsError(t *testing.T) {
+	test := []struct {
+		name    string
+		err     error
+		code    service.Code
+		details map[string]interface{}
+	}{
+		{
+			name: "service error",
+			err:  service.ErrUserDoesNotExists,
+			code: service.CodeNotFound,
+		},
+		{
+			name: "wrapped service error",
+			err:  fmt.Errorf("get user failed: %w", service.ErrUserAlreadyExists),
+			code: service.CodeAlreadyExists,
+		},
+		{
+			name:    "with details",
+			err:     fmt.Errorf("sing in failed: %w", &service.LockedError{Key: "phone:+79001234567", Retry: 1500 * time.Millisecond}),
+			code:    service.CodeResourceExhausted,
+			details: map[string]interface{}{"retry_after": 2},
+		},
+		{
+			name: "internal error",
+			err:  fmt.Errorf("connection refused"),
+			code: service.CodeInternal,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			e := service.AsError(tt.err)
+
+			assert.Equal(t, e.Code, tt.code)
+			assert.Equal(t, e.Details, tt.details)
+		})
+	}
+}
+
+func TestErrorWithDetails(t *testing.T) {
+	err := service.ErrValidation.WithDetails(map[string]interface{}{"fields": map[string]string{"name": "is required"}})
+
+	assert.Equal(t, errors.Is(err, service.ErrValidation), true)
+	assert.Equal(t, errors.Is(err, service.ErrRateLimited), false)
+	assert.Equal(t, service.ErrValidation.Details == nil, true)
+}
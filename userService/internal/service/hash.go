@@ -0,0 +1,169 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	HasherBcrypt   string = "bcrypt"
+	HasherArgon2id string = "argon2id"
+)
+
+var ErrUnknownHashFormat = fmt.Errorf("unknown hash format")
+
+// PasswordHasher hashes passwords into self-describing PHC strings, so the
+// scheme and its parameters are stored alongside every hash.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Compare reports whether password matches hash. It returns
+	// ErrUnknownHashFormat if hash wasn't produced by this hasher.
+	Compare(hash, password string) (bool, error)
+	// NeedsRehash reports whether hash was produced by another scheme or
+	// with other parameters and should be replaced.
+	NeedsRehash(hash string) bool
+}
+
+// NewPasswordHasher returns the hasher for the scheme name. Argon2id is used
+// unless bcrypt is asked for.
+func NewPasswordHasher(name string) PasswordHasher {
+	if name == HasherBcrypt {
+		return NewBcryptHasher(bcrypt.DefaultCost)
+	}
+	return NewArgon2idHasher()
+}
+
+// hasherOf returns the hasher able to check hash, so passwords hashed before
+// the scheme was switched still can be verified. It returns nil for the
+// legacy salted SHA-1 hashes.
+func hasherOf(hash string) PasswordHasher {
+	if strings.HasPrefix(hash, "$"+HasherArgon2id+"$") {
+		return NewArgon2idHasher()
+	}
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(hash, prefix) {
+			return NewBcryptHasher(bcrypt.DefaultCost)
+		}
+	}
+	return nil
+}
+
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", fmt.Errorf("generate from password failed: %w", err)
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Compare(hash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return false, fmt.Errorf("compare hash and password failed: %v: %w", err, ErrUnknownHashFormat)
+	}
+	return true, nil
+}
+
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
+
+type Argon2idHasher struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	saltLen uint32
+	keyLen  uint32
+}
+
+// NewArgon2idHasher returns the hasher with the parameters recommended by
+// RFC 9106 for memory constrained environments.
+func NewArgon2idHasher() *Argon2idHasher {
+	return &Argon2idHasher{
+		time:    3,
+		memory:  64 * 1024,
+		threads: 4,
+		saltLen: 16,
+		keyLen:  32,
+	}
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.saltLen)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", fmt.Errorf("read failed: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.time, h.memory, h.threads, h.keyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.memory, h.time, h.threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *Argon2idHasher) Compare(hash, password string) (bool, error) {
+	params, salt, key, err := h.decode(hash)
+	if err != nil {
+		return false, fmt.Errorf("decode failed: %w", err)
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+	params, salt, key, err := h.decode(hash)
+	if err != nil {
+		return true
+	}
+	return params.time != h.time || params.memory != h.memory || params.threads != h.threads ||
+		uint32(len(salt)) != h.saltLen || uint32(len(key)) != h.keyLen
+}
+
+func (h *Argon2idHasher) decode(hash string) (*Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != HasherArgon2id {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+
+	params := &Argon2idHasher{}
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads)
+	if err != nil {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 || params.threads == 0 {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+	return params, salt, key, nil
+}
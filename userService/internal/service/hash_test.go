@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	test := []struct {
+		name        string
+		hasher      service.PasswordHasher
+		hash        string
+		password    string
+		ok          bool
+		needsRehash bool
+		err         error
+	}{
+		{
+			name:        "argon2id correct password",
+			hasher:      service.NewArgon2idHasher(),
+			password:    "12345",
+			ok:          true,
+			needsRehash: false,
+			err:         nil,
+		},
+		{
+			name:        "argon2id other params",
+			hasher:      service.NewArgon2idHasher(),
+			hash:        "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$fP3z1mRo6GuoP0Vmc8cTcWq60gZKgI0wuo6kPwr/bHg",
+			password:    "12345",
+			ok:          false,
+			needsRehash: true,
+			err:         nil,
+		},
+		{
+			name:        "argon2id unknown format",
+			hasher:      service.NewArgon2idHasher(),
+			hash:        "$2a$04$abc",
+			password:    "12345",
+			ok:          false,
+			needsRehash: true,
+			err:         service.ErrUnknownHashFormat,
+		},
+		{
+			name:        "bcrypt correct password",
+			hasher:      service.NewBcryptHasher(4),
+			password:    "12345",
+			ok:          true,
+			needsRehash: false,
+			err:         nil,
+		},
+		{
+			name:        "bcrypt unknown format",
+			hasher:      service.NewBcryptHasher(4),
+			hash:        "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$fP3z1mRo6GuoP0Vmc8cTcWq60gZKgI0wuo6kPwr/bHg",
+			password:    "12345",
+			ok:          false,
+			needsRehash: true,
+			err:         service.ErrUnknownHashFormat,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.hash == "" {
+				var err error
+				tt.hash, err = tt.hasher.Hash(tt.password)
+				assert.Equal(t, err, nil)
+			}
+
+			ok, err := tt.hasher.Compare(tt.hash, tt.password)
+			assert.Equal(t, ok, tt.ok)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			assert.Equal(t, tt.hasher.NeedsRehash(tt.hash), tt.needsRehash)
+
+			ok, _ = tt.hasher.Compare(tt.hash, "wrong")
+			assert.Equal(t, ok, false)
+		})
+	}
+}
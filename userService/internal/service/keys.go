@@ -0,0 +1,211 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/golang-jwt/jwt"
+)
+
+var (
+	ErrUnknownKey         = fmt.Errorf("unknown key")
+	ErrNoKeys             = fmt.Errorf("no keys")
+	ErrUnsupportedKeyType = fmt.Errorf("unsupported key type")
+)
+
+// Key is a private key tokens are signed with. Its id is written to the kid
+// header of the tokens.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+}
+
+func NewKey(id string, private crypto.Signer) (*Key, error) {
+	switch private.(type) {
+	case *rsa.PrivateKey:
+		return &Key{id, jwt.SigningMethodRS256, private}, nil
+	case ed25519.PrivateKey:
+		return &Key{id, jwt.SigningMethodEdDSA, private}, nil
+	}
+	return nil, fmt.Errorf("key %v: %T: %w", id, private, ErrUnsupportedKeyType)
+}
+
+// KeySet holds the keys tokens are signed and verified with. Tokens are
+// signed with the key of signing kid, every key of the set can verify them,
+// so a new key can be rolled out while tokens of the old one are still valid.
+// If the set has no keys, tokens are signed with the HS256 secret.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	secret  []byte
+}
+
+func NewKeySet(secret, signingKid string, keys ...*Key) (*KeySet, error) {
+	set := &KeySet{
+		keys:   make(map[string]*Key, len(keys)),
+		secret: []byte(secret),
+	}
+	for _, key := range keys {
+		set.keys[key.ID] = key
+	}
+
+	if len(keys) == 0 {
+		return set, nil
+	}
+
+	signing, ok := set.keys[signingKid]
+	if !ok {
+		return nil, fmt.Errorf("signing kid %v: %w", signingKid, ErrUnknownKey)
+	}
+	set.signing = signing
+	return set, nil
+}
+
+// LoadKeySet loads every PEM private key of JWT_KEYS_DIR, the file name
+// without extension is the key's id. The HS256 secret is used when the
+// directory isn't set, a directory without keys is an error.
+func LoadKeySet(cfg *config.Config) (*KeySet, error) {
+	if cfg.JWT_KEYS_DIR == "" {
+		return NewKeySet(cfg.HS256_SECRET, "")
+	}
+
+	files, err := filepath.Glob(filepath.Join(cfg.JWT_KEYS_DIR, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("glob failed: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("dir %v: %w", cfg.JWT_KEYS_DIR, ErrNoKeys)
+	}
+
+	keys := make([]*Key, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read file failed: %w", err)
+		}
+
+		private, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse private key %v failed: %w", file, err)
+		}
+
+		key, err := NewKey(strings.TrimSuffix(filepath.Base(file), ".pem"), private)
+		if err != nil {
+			return nil, fmt.Errorf("new key failed: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeySet(cfg.HS256_SECRET, cfg.JWT_KID, keys...)
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no pem block")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse pkcs8 private key failed: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedKeyType
+	}
+	return signer, nil
+}
+
+func (k *KeySet) sign(claims jwt.MapClaims) (string, error) {
+	if k.signing == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.secret)
+	}
+
+	token := jwt.NewWithClaims(k.signing.Method, claims)
+	token.Header["kid"] = k.signing.ID
+	return token.SignedString(k.signing.Private)
+}
+
+// keyfunc returns the key of the token's kid. The algorithm must be the one of
+// the key, otherwise a public key could be used as an HMAC secret.
+func (k *KeySet) keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		if token.Method != jwt.SigningMethodHS256 || len(k.secret) == 0 {
+			return nil, ErrUnknownKey
+		}
+		return k.secret, nil
+	}
+
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("kid %v: %w", kid, ErrUnknownKey)
+	}
+	if token.Method != key.Method {
+		return nil, fmt.Errorf("alg %v: %w", token.Method.Alg(), ErrUnknownKey)
+	}
+	return key.Private.Public(), nil
+}
+
+// JWK is a public key in the JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the set. The HS256 secret is never
+// published.
+func (k *KeySet) JWKS() *JWKS {
+	jwks := &JWKS{Keys: make([]JWK, 0, len(k.keys))}
+	for _, key := range k.keys {
+		jwk := JWK{
+			Kid: key.ID,
+			Use: "sig",
+			Alg: key.Method.Alg(),
+		}
+
+		switch public := key.Private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+	return jwks
+}
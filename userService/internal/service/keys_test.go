@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang-jwt/jwt"
+)
+
+func TestKeySet(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	rs256, _ := service.NewKey("rs-1", rsaKey)
+	eddsa, _ := service.NewKey("ed-1", edKey)
+
+	newToken := func(keys *service.KeySet) string {
+		token, _ := service.NewToken(service.TokenParams{
+			ID:                uint64(1),
+			Type:              service.User,
+			Keys:              keys,
+			ACCESS_TOKEN_EXP:  1,
+			REFRESH_TOKEN_EXP: 1,
+		})
+		return token.Access
+	}
+
+	rsOnly, _ := service.NewKeySet("", "rs-1", rs256)
+	edOnly, _ := service.NewKeySet("", "ed-1", eddsa)
+	rotated, _ := service.NewKeySet("", "ed-1", rs256, eddsa)
+	withSecret, _ := service.NewKeySet("QWERTfg53gxb2", "ed-1", eddsa)
+
+	// A token signed with HS256 and the public key as the secret must not be
+	// accepted by a set which has the key.
+	public, _ := x509.MarshalPKIXPublicKey(rsaKey.Public())
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":   1,
+		"type":      service.User,
+		"token_use": service.TokenUseAccess,
+		"iss":       service.Issuer,
+		"aud":       service.Audience,
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	})
+	hmacToken.Header["kid"] = "rs-1"
+	confused, _ := hmacToken.SignedString(public)
+
+	test := []struct {
+		name   string
+		token  string
+		keys   *service.KeySet
+		userId uint64
+		err    error
+	}{
+		{
+			name:   "rs256",
+			token:  newToken(rsOnly),
+			keys:   rsOnly,
+			userId: 1,
+			err:    nil,
+		},
+		{
+			name:   "eddsa",
+			token:  newToken(edOnly),
+			keys:   edOnly,
+			userId: 1,
+			err:    nil,
+		},
+		{
+			name:   "token of rotated out signing key",
+			token:  newToken(rsOnly),
+			keys:   rotated,
+			userId: 1,
+			err:    nil,
+		},
+		{
+			name:   "unknown kid",
+			token:  newToken(rsOnly),
+			keys:   edOnly,
+			userId: 0,
+			err:    service.ErrInvalidToken,
+		},
+		{
+			name:   "hs256 fallback",
+			token:  newToken(hs256Keys),
+			keys:   withSecret,
+			userId: 1,
+			err:    nil,
+		},
+		{
+			name:   "hs256 without secret",
+			token:  newToken(hs256Keys),
+			keys:   edOnly,
+			userId: 0,
+			err:    service.ErrInvalidToken,
+		},
+		{
+			name:   "public key as hmac secret",
+			token:  confused,
+			keys:   rsOnly,
+			userId: 0,
+			err:    service.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := service.Verify(tt.token, service.TokenUseAccess, tt.keys)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			assert.Equal(t, id, tt.userId)
+		})
+	}
+
+	jwks := rotated.JWKS()
+	assert.Equal(t, len(jwks.Keys), 2)
+	assert.Equal(t, jwks.Keys[0].Kid, "ed-1")
+	assert.Equal(t, jwks.Keys[0].Kty, "OKP")
+	assert.Equal(t, jwks.Keys[0].Alg, "EdDSA")
+	assert.Equal(t, jwks.Keys[1].Kid, "rs-1")
+	assert.Equal(t, jwks.Keys[1].Kty, "RSA")
+	assert.Equal(t, jwks.Keys[1].E, "AQAB")
+
+	assert.Equal(t, len(withSecret.JWKS().Keys), 1)
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	edDer, _ := x509.MarshalPKCS8PrivateKey(edKey)
+
+	writeKey := func(name, blockType string, der []byte) {
+		data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+		err := os.WriteFile(filepath.Join(dir, name), data, 0600)
+		if err != nil {
+			t.Fatalf("write file failed: %v", err)
+		}
+	}
+	writeKey("2023-01.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	writeKey("2023-02.pem", "PRIVATE KEY", edDer)
+
+	test := []struct {
+		name string
+		cfg  *config.Config
+		keys int
+		err  error
+	}{
+		{
+			name: "load keys",
+			cfg:  &config.Config{JWT_KEYS_DIR: dir, JWT_KID: "2023-02"},
+			keys: 2,
+			err:  nil,
+		},
+		{
+			name: "unknown signing kid",
+			cfg:  &config.Config{JWT_KEYS_DIR: dir, JWT_KID: "2023-03"},
+			keys: 0,
+			err:  service.ErrUnknownKey,
+		},
+		{
+			name: "no keys in dir",
+			cfg:  &config.Config{JWT_KEYS_DIR: t.TempDir(), HS256_SECRET: "QWERTfg53gxb2"},
+			keys: 0,
+			err:  service.ErrNoKeys,
+		},
+		{
+			name: "hs256",
+			cfg:  &config.Config{HS256_SECRET: "QWERTfg53gxb2"},
+			keys: 0,
+			err:  nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := service.LoadKeySet(tt.cfg)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			if tt.err == nil {
+				assert.Equal(t, len(keys.JWKS().Keys), tt.keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	defaultLoginAttempts   = 5
+	defaultLoginIPAttempts = 20
+	defaultLoginWindow     = 15
+	defaultLoginLockout    = 15
+)
+
+var ErrSignInLocked = NewError(CodeResourceExhausted, "too many sign in attempts")
+
+// LoginRepo counts failed sign ins in a sliding window and keeps the locks
+// of the phone numbers and IPs with too many of them.
+type LoginRepo interface {
+	// AddFailedLogin counts a failed sign in of key and returns the number of
+	// failed sign ins within the last window.
+	AddFailedLogin(ctx context.Context, key string, window time.Duration) (int, error)
+	DeleteFailedLogins(ctx context.Context, key string) error
+	LockLogin(ctx context.Context, key string, expired time.Duration) error
+	// GetLoginLock returns the time left until key is unlocked, 0 if it
+	// isn't locked.
+	GetLoginLock(ctx context.Context, key string) (time.Duration, error)
+}
+
+// LockedError is returned when sign in isn't allowed for a while. Err is the
+// reason of the failed attempt which set the lock, nil if the attempt was
+// rejected because of a lock set before.
+type LockedError struct {
+	Err   error
+	Key   string
+	Retry time.Duration
+	// Lockout is set when the attempt locked key for the whole lockout time.
+	Lockout bool
+}
+
+func (e *LockedError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%v, retry after %v", ErrSignInLocked, e.Retry)
+	}
+	return fmt.Sprintf("%v, retry after %v", e.Err, e.Retry)
+}
+
+// Unwrap returns the reason of the failed attempt, or ErrSignInLocked with
+// the seconds to wait in the retry_after detail.
+func (e *LockedError) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+	return ErrSignInLocked.WithDetails(map[string]interface{}{
+		"retry_after": int(math.Ceil(e.Retry.Seconds())),
+	})
+}
+
+func (e *LockedError) Is(target error) bool {
+	return target == ErrSignInLocked
+}
+
+func phoneLoginKey(phone string) string {
+	return "phone:" + phone
+}
+
+func ipLoginKey(ip string) string {
+	return "ip:" + ip
+}
+
+// loginKeys returns the keys failed sign ins are counted for with the number
+// of failures which locks each of them. An IP gets more attempts than a phone
+// number, many users can share it.
+func (s *AuthService) loginKeys(user UserSingIn) map[string]int {
+	attempts := s.cfg.LOGIN_ATTEMPTS
+	if attempts == 0 {
+		attempts = defaultLoginAttempts
+	}
+	ipAttempts := s.cfg.LOGIN_IP_ATTEMPTS
+	if ipAttempts == 0 {
+		ipAttempts = defaultLoginIPAttempts
+	}
+
+	keys := map[string]int{phoneLoginKey(user.PhoneNumber): attempts}
+	if user.IP != "" {
+		keys[ipLoginKey(user.IP)] = ipAttempts
+	}
+	return keys
+}
+
+func (s *AuthService) loginWindow() time.Duration {
+	if s.cfg.LOGIN_WINDOW == 0 {
+		return defaultLoginWindow * time.Minute
+	}
+	return time.Duration(s.cfg.LOGIN_WINDOW) * time.Minute
+}
+
+func (s *AuthService) loginLockout() time.Duration {
+	if s.cfg.LOGIN_LOCKOUT == 0 {
+		return defaultLoginLockout * time.Minute
+	}
+	return time.Duration(s.cfg.LOGIN_LOCKOUT) * time.Minute
+}
+
+// checkLoginLock returns LockedError if the phone number or the IP of the
+// sign in is locked.
+func (s *AuthService) checkLoginLock(ctx context.Context, user UserSingIn) error {
+	for key := range s.loginKeys(user) {
+		retry, err := s.GetLoginLock(ctx, key)
+		if err != nil {
+			return fmt.Errorf("get login lock failed: %w", err)
+		}
+		if retry > 0 {
+			return &LockedError{Key: key, Retry: retry}
+		}
+	}
+	return nil
+}
+
+// loginDelay returns the delay after the failed sign in of the phone number,
+// doubling from a second with every failure after the first one. The delay is
+// capped at the lockout time.
+func (s *AuthService) loginDelay(failed int) time.Duration {
+	delay := time.Second
+	for i := 2; i < failed && delay < s.loginLockout(); i++ {
+		delay *= 2
+	}
+	if delay > s.loginLockout() {
+		return s.loginLockout()
+	}
+	return delay
+}
+
+// failLogin counts the failed sign in. The phone number is locked for a delay
+// doubling with every failure after the first one, and both the phone number
+// and the IP for the lockout time once they reach their attempts. It returns
+// reason wrapped in LockedError if the sign in was locked.
+func (s *AuthService) failLogin(ctx context.Context, user UserSingIn, reason error) error {
+	var locked *LockedError
+	for key, attempts := range s.loginKeys(user) {
+		failed, err := s.AddFailedLogin(ctx, key, s.loginWindow())
+		if err != nil {
+			return fmt.Errorf("add failed login failed: %w", err)
+		}
+
+		lock := &LockedError{Err: reason, Key: key}
+		switch {
+		case failed >= attempts:
+			lock.Retry, lock.Lockout = s.loginLockout(), true
+		case failed > 1 && key == phoneLoginKey(user.PhoneNumber):
+			lock.Retry = s.loginDelay(failed)
+		default:
+			continue
+		}
+
+		err = s.LockLogin(ctx, key, lock.Retry)
+		if err != nil {
+			return fmt.Errorf("lock login failed: %w", err)
+		}
+		if locked == nil || lock.Retry > locked.Retry {
+			locked = lock
+		}
+	}
+
+	if locked != nil {
+		return locked
+	}
+	return reason
+}
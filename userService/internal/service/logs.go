@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+)
+
+const (
+	defaultLogsLimit = 50
+	maxLogsLimit     = 500
+)
+
+// LogsFilter selects the log entries of the admin list, newest first. Request
+// ID matches the uuid of the entries written before request IDs too, URL is
+// matched by prefix. From is inclusive, To exclusive.
+type LogsFilter struct {
+	Level     string    `form:"level" binding:"omitempty,oneof=debug info warn error dpanic panic fatal"`
+	From      time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	RequestID string    `form:"request_id"`
+	Method    string    `form:"method"`
+	URL       string    `form:"url"`
+	Limit     int       `form:"limit" binding:"min=0"`
+	Cursor    string    `form:"cursor"`
+
+	// After is set from Cursor by the service.
+	After *LogsCursor `form:"-"`
+}
+
+// LogsCursor is the last entry of the previous page.
+type LogsCursor struct {
+	Time time.Time `json:"time"`
+	ID   string    `json:"id"`
+}
+
+type LogsPage struct {
+	Logs       []*model.Log `json:"logs"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+type LogRepo interface {
+	// GetLogsByFilter returns up to one entry more than the filter's limit,
+	// so the caller knows whether there is a next page.
+	GetLogsByFilter(ctx context.Context, filter *LogsFilter) ([]*model.Log, error)
+	// ExportLogs passes every entry of the filter to fn, ignoring its limit,
+	// and stops at the first error of fn.
+	ExportLogs(ctx context.Context, filter *LogsFilter, fn func(*model.Log) error) error
+}
+
+type LogService struct {
+	LogRepo
+}
+
+func NewLogService(mongo LogRepo) *LogService {
+	return &LogService{mongo}
+}
+
+func (s *LogService) ListLogs(ctx context.Context, filter *LogsFilter) (*LogsPage, error) {
+	err := normalizeLogsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.GetLogsByFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get logs by filter failed: %w", err)
+	}
+
+	page := &LogsPage{Logs: logs}
+	if len(logs) > filter.Limit {
+		page.Logs = logs[:filter.Limit]
+
+		last := page.Logs[len(page.Logs)-1]
+		page.NextCursor, err = encodeCursor(&LogsCursor{last.Time, last.ID})
+		if err != nil {
+			return nil, fmt.Errorf("encode cursor failed: %w", err)
+		}
+	}
+	return page, nil
+}
+
+// ExportLogs passes every entry of the filter to fn, starting after its
+// cursor.
+func (s *LogService) ExportLogs(ctx context.Context, filter *LogsFilter, fn func(*model.Log) error) error {
+	err := normalizeLogsFilter(filter)
+	if err != nil {
+		return err
+	}
+	return s.LogRepo.ExportLogs(ctx, filter, fn)
+}
+
+func normalizeLogsFilter(filter *LogsFilter) error {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return fmt.Errorf("from is not before to: %w", ErrBadFilter)
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultLogsLimit
+	case filter.Limit < 0 || filter.Limit > maxLogsLimit:
+		return fmt.Errorf("limit must be from 1 to %v: %w", maxLogsLimit, ErrBadFilter)
+	}
+
+	if filter.Cursor != "" {
+		var after LogsCursor
+		err := decodeCursor(filter.Cursor, &after)
+		if err != nil {
+			return fmt.Errorf("cursor: %v: %w", err, ErrBadFilter)
+		}
+		filter.After = &after
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/RipperAcskt/innotaxi/internal/service/mocks"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+)
+
+func TestListLogs(t *testing.T) {
+	type mockBehavior func(s *mocks.MockLogRepo)
+
+	now := time.Date(2023, 3, 10, 12, 0, 0, 0, time.UTC)
+	logs := []*model.Log{
+		{ID: "640b1c000000000000000003", Time: now},
+		{ID: "640b1c000000000000000002", Time: now.Add(-time.Minute)},
+		{ID: "640b1c000000000000000001", Time: now.Add(-2 * time.Minute)},
+	}
+
+	test := []struct {
+		name         string
+		filter       service.LogsFilter
+		mockBehavior mockBehavior
+		logs         int
+		next         bool
+		err          error
+	}{
+		{
+			name:   "first page",
+			filter: service.LogsFilter{Level: "error", Limit: 2},
+			mockBehavior: func(s *mocks.MockLogRepo) {
+				s.EXPECT().GetLogsByFilter(context.Background(), gomock.Any()).Return(logs, nil)
+			},
+			logs: 2,
+			next: true,
+			err:  nil,
+		},
+		{
+			name:   "last page",
+			filter: service.LogsFilter{Cursor: "eyJ0aW1lIjoiMjAyMy0wMy0xMFQxMTo1OTowMFoiLCJpZCI6IjY0MGIxYzAwMDAwMDAwMDAwMDAwMDAwMiJ9"},
+			mockBehavior: func(s *mocks.MockLogRepo) {
+				s.EXPECT().GetLogsByFilter(context.Background(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *service.LogsFilter) ([]*model.Log, error) {
+					assert.Equal(t, filter.Limit, 50)
+					assert.Equal(t, filter.After, &service.LogsCursor{Time: now.Add(-time.Minute), ID: "640b1c000000000000000002"})
+					return logs[2:], nil
+				})
+			},
+			logs: 1,
+			next: false,
+			err:  nil,
+		},
+		{
+			name:         "from after to",
+			filter:       service.LogsFilter{From: now, To: now.Add(-time.Hour)},
+			mockBehavior: func(s *mocks.MockLogRepo) {},
+			err:          service.ErrBadFilter,
+		},
+		{
+			name:         "bad cursor",
+			filter:       service.LogsFilter{Cursor: "!"},
+			mockBehavior: func(s *mocks.MockLogRepo) {},
+			err:          service.ErrBadFilter,
+		},
+		{
+			name:         "limit too big",
+			filter:       service.LogsFilter{Limit: 1000},
+			mockBehavior: func(s *mocks.MockLogRepo) {},
+			err:          service.ErrBadFilter,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logRepo := mocks.NewMockLogRepo(ctrl)
+			logService := service.NewLogService(logRepo)
+
+			tt.mockBehavior(logRepo)
+
+			service := service.Service{
+				LogService: logService,
+			}
+
+			page, err := service.ListLogs(context.Background(), &tt.filter)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			if tt.err == nil {
+				assert.Equal(t, len(page.Logs), tt.logs)
+				assert.Equal(t, page.NextCursor != "", tt.next)
+			}
+		})
+	}
+}
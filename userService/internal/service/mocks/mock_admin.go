@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi/internal/service (interfaces: AdminRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/RipperAcskt/innotaxi/internal/model"
+	service "github.com/RipperAcskt/innotaxi/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdminRepo is a mock of AdminRepo interface.
+type MockAdminRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminRepoMockRecorder
+}
+
+// MockAdminRepoMockRecorder is the mock recorder for MockAdminRepo.
+type MockAdminRepoMockRecorder struct {
+	mock *MockAdminRepo
+}
+
+// NewMockAdminRepo creates a new mock instance.
+func NewMockAdminRepo(ctrl *gomock.Controller) *MockAdminRepo {
+	mock := &MockAdminRepo{ctrl: ctrl}
+	mock.recorder = &MockAdminRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminRepo) EXPECT() *MockAdminRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUsersByFilter mocks base method.
+func (m *MockAdminRepo) GetUsersByFilter(arg0 context.Context, arg1 *service.UsersFilter) ([]*model.UserRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByFilter", arg0, arg1)
+	ret0, _ := ret[0].([]*model.UserRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByFilter indicates an expected call of GetUsersByFilter.
+func (mr *MockAdminRepoMockRecorder) GetUsersByFilter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByFilter", reflect.TypeOf((*MockAdminRepo)(nil).GetUsersByFilter), arg0, arg1)
+}
+
+// RestoreUserById mocks base method.
+func (m *MockAdminRepo) RestoreUserById(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUserById", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUserById indicates an expected call of RestoreUserById.
+func (mr *MockAdminRepoMockRecorder) RestoreUserById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUserById", reflect.TypeOf((*MockAdminRepo)(nil).RestoreUserById), arg0, arg1)
+}
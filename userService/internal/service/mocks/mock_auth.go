@@ -35,6 +35,35 @@ func (m *MockAuthRepo) EXPECT() *MockAuthRepoMockRecorder {
 	return m.recorder
 }
 
+// ActivateUserByPhoneNumber mocks base method.
+func (m *MockAuthRepo) ActivateUserByPhoneNumber(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateUserByPhoneNumber", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateUserByPhoneNumber indicates an expected call of ActivateUserByPhoneNumber.
+func (mr *MockAuthRepoMockRecorder) ActivateUserByPhoneNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUserByPhoneNumber", reflect.TypeOf((*MockAuthRepo)(nil).ActivateUserByPhoneNumber), arg0, arg1)
+}
+
+// CheckUserByEmail mocks base method.
+func (m *MockAuthRepo) CheckUserByEmail(arg0 context.Context, arg1 string) (*service.UserSingIn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(*service.UserSingIn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckUserByEmail indicates an expected call of CheckUserByEmail.
+func (mr *MockAuthRepoMockRecorder) CheckUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserByEmail", reflect.TypeOf((*MockAuthRepo)(nil).CheckUserByEmail), arg0, arg1)
+}
+
 // CheckUserByPhoneNumber mocks base method.
 func (m *MockAuthRepo) CheckUserByPhoneNumber(arg0 context.Context, arg1 string) (*service.UserSingIn, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +92,32 @@ func (mr *MockAuthRepoMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthRepo)(nil).CreateUser), arg0, arg1)
 }
+
+// GetPasswordById mocks base method.
+func (m *MockAuthRepo) GetPasswordById(arg0 context.Context, arg1 uint64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordById", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordById indicates an expected call of GetPasswordById.
+func (mr *MockAuthRepoMockRecorder) GetPasswordById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordById", reflect.TypeOf((*MockAuthRepo)(nil).GetPasswordById), arg0, arg1)
+}
+
+// UpdatePasswordById mocks base method.
+func (m *MockAuthRepo) UpdatePasswordById(arg0 context.Context, arg1 uint64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasswordById indicates an expected call of UpdatePasswordById.
+func (mr *MockAuthRepoMockRecorder) UpdatePasswordById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordById", reflect.TypeOf((*MockAuthRepo)(nil).UpdatePasswordById), arg0, arg1, arg2)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi/internal/service (interfaces: DriverRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/RipperAcskt/innotaxi/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDriverRepo is a mock of DriverRepo interface.
+type MockDriverRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDriverRepoMockRecorder
+}
+
+// MockDriverRepoMockRecorder is the mock recorder for MockDriverRepo.
+type MockDriverRepoMockRecorder struct {
+	mock *MockDriverRepo
+}
+
+// NewMockDriverRepo creates a new mock instance.
+func NewMockDriverRepo(ctrl *gomock.Controller) *MockDriverRepo {
+	mock := &MockDriverRepo{ctrl: ctrl}
+	mock.recorder = &MockDriverRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDriverRepo) EXPECT() *MockDriverRepoMockRecorder {
+	return m.recorder
+}
+
+// FindFreeDriver mocks base method.
+func (m *MockDriverRepo) FindFreeDriver(arg0 context.Context, arg1 *model.Order) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFreeDriver", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFreeDriver indicates an expected call of FindFreeDriver.
+func (mr *MockDriverRepoMockRecorder) FindFreeDriver(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFreeDriver", reflect.TypeOf((*MockDriverRepo)(nil).FindFreeDriver), arg0, arg1)
+}
+
+// RateDriver mocks base method.
+func (m *MockDriverRepo) RateDriver(arg0 context.Context, arg1 *model.Order, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateDriver", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RateDriver indicates an expected call of RateDriver.
+func (mr *MockDriverRepoMockRecorder) RateDriver(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateDriver", reflect.TypeOf((*MockDriverRepo)(nil).RateDriver), arg0, arg1, arg2)
+}
+
+// ReleaseDriver mocks base method.
+func (m *MockDriverRepo) ReleaseDriver(arg0 context.Context, arg1 *model.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDriver", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDriver indicates an expected call of ReleaseDriver.
+func (mr *MockDriverRepoMockRecorder) ReleaseDriver(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDriver", reflect.TypeOf((*MockDriverRepo)(nil).ReleaseDriver), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi/internal/service (interfaces: LogRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/RipperAcskt/innotaxi/internal/model"
+	service "github.com/RipperAcskt/innotaxi/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLogRepo is a mock of LogRepo interface.
+type MockLogRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogRepoMockRecorder
+}
+
+// MockLogRepoMockRecorder is the mock recorder for MockLogRepo.
+type MockLogRepoMockRecorder struct {
+	mock *MockLogRepo
+}
+
+// NewMockLogRepo creates a new mock instance.
+func NewMockLogRepo(ctrl *gomock.Controller) *MockLogRepo {
+	mock := &MockLogRepo{ctrl: ctrl}
+	mock.recorder = &MockLogRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogRepo) EXPECT() *MockLogRepoMockRecorder {
+	return m.recorder
+}
+
+// ExportLogs mocks base method.
+func (m *MockLogRepo) ExportLogs(arg0 context.Context, arg1 *service.LogsFilter, arg2 func(*model.Log) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportLogs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportLogs indicates an expected call of ExportLogs.
+func (mr *MockLogRepoMockRecorder) ExportLogs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportLogs", reflect.TypeOf((*MockLogRepo)(nil).ExportLogs), arg0, arg1, arg2)
+}
+
+// GetLogsByFilter mocks base method.
+func (m *MockLogRepo) GetLogsByFilter(arg0 context.Context, arg1 *service.LogsFilter) ([]*model.Log, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogsByFilter", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogsByFilter indicates an expected call of GetLogsByFilter.
+func (mr *MockLogRepoMockRecorder) GetLogsByFilter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogsByFilter", reflect.TypeOf((*MockLogRepo)(nil).GetLogsByFilter), arg0, arg1)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi/internal/service (interfaces: OrderRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/RipperAcskt/innotaxi/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderRepo is a mock of OrderRepo interface.
+type MockOrderRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepoMockRecorder
+}
+
+// MockOrderRepoMockRecorder is the mock recorder for MockOrderRepo.
+type MockOrderRepoMockRecorder struct {
+	mock *MockOrderRepo
+}
+
+// NewMockOrderRepo creates a new mock instance.
+func NewMockOrderRepo(ctrl *gomock.Controller) *MockOrderRepo {
+	mock := &MockOrderRepo{ctrl: ctrl}
+	mock.recorder = &MockOrderRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepo) EXPECT() *MockOrderRepoMockRecorder {
+	return m.recorder
+}
+
+// AddOrder mocks base method.
+func (m *MockOrderRepo) AddOrder(arg0 context.Context, arg1 *model.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrder indicates an expected call of AddOrder.
+func (mr *MockOrderRepoMockRecorder) AddOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockOrderRepo)(nil).AddOrder), arg0, arg1)
+}
+
+// FinishOrderByDriverId mocks base method.
+func (m *MockOrderRepo) FinishOrderByDriverId(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishOrderByDriverId", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishOrderByDriverId indicates an expected call of FinishOrderByDriverId.
+func (mr *MockOrderRepoMockRecorder) FinishOrderByDriverId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishOrderByDriverId", reflect.TypeOf((*MockOrderRepo)(nil).FinishOrderByDriverId), arg0, arg1)
+}
+
+// GetLastOrderByUserId mocks base method.
+func (m *MockOrderRepo) GetLastOrderByUserId(arg0 context.Context, arg1 string) (*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastOrderByUserId", arg0, arg1)
+	ret0, _ := ret[0].(*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastOrderByUserId indicates an expected call of GetLastOrderByUserId.
+func (mr *MockOrderRepoMockRecorder) GetLastOrderByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastOrderByUserId", reflect.TypeOf((*MockOrderRepo)(nil).GetLastOrderByUserId), arg0, arg1)
+}
+
+// GetOrdersByUserId mocks base method.
+func (m *MockOrderRepo) GetOrdersByUserId(arg0 context.Context, arg1 string) ([]*model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByUserId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByUserId indicates an expected call of GetOrdersByUserId.
+func (mr *MockOrderRepoMockRecorder) GetOrdersByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUserId", reflect.TypeOf((*MockOrderRepo)(nil).GetOrdersByUserId), arg0, arg1)
+}
+
+// HasOrderInProgress mocks base method.
+func (m *MockOrderRepo) HasOrderInProgress(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOrderInProgress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOrderInProgress indicates an expected call of HasOrderInProgress.
+func (mr *MockOrderRepoMockRecorder) HasOrderInProgress(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOrderInProgress", reflect.TypeOf((*MockOrderRepo)(nil).HasOrderInProgress), arg0, arg1, arg2)
+}
+
+// SetOrderRating mocks base method.
+func (m *MockOrderRepo) SetOrderRating(arg0 context.Context, arg1 *model.Order, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderRating", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOrderRating indicates an expected call of SetOrderRating.
+func (mr *MockOrderRepoMockRecorder) SetOrderRating(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderRating", reflect.TypeOf((*MockOrderRepo)(nil).SetOrderRating), arg0, arg1, arg2)
+}
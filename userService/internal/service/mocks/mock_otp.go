@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi/internal/service (interfaces: OTPRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOTPRepo is a mock of OTPRepo interface.
+type MockOTPRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOTPRepoMockRecorder
+}
+
+// MockOTPRepoMockRecorder is the mock recorder for MockOTPRepo.
+type MockOTPRepoMockRecorder struct {
+	mock *MockOTPRepo
+}
+
+// NewMockOTPRepo creates a new mock instance.
+func NewMockOTPRepo(ctrl *gomock.Controller) *MockOTPRepo {
+	mock := &MockOTPRepo{ctrl: ctrl}
+	mock.recorder = &MockOTPRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOTPRepo) EXPECT() *MockOTPRepoMockRecorder {
+	return m.recorder
+}
+
+// AddOTP mocks base method.
+func (m *MockOTPRepo) AddOTP(arg0 context.Context, arg1, arg2, arg3 string, arg4 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOTP", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOTP indicates an expected call of AddOTP.
+func (mr *MockOTPRepoMockRecorder) AddOTP(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOTP", reflect.TypeOf((*MockOTPRepo)(nil).AddOTP), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CheckOTP mocks base method.
+func (m *MockOTPRepo) CheckOTP(arg0 context.Context, arg1, arg2 string, arg3 int) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOTP", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckOTP indicates an expected call of CheckOTP.
+func (mr *MockOTPRepoMockRecorder) CheckOTP(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOTP", reflect.TypeOf((*MockOTPRepo)(nil).CheckOTP), arg0, arg1, arg2, arg3)
+}
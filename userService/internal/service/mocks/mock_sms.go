@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/RipperAcskt/innotaxi/internal/service (interfaces: SmsSender)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSmsSender is a mock of SmsSender interface.
+type MockSmsSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmsSenderMockRecorder
+}
+
+// MockSmsSenderMockRecorder is the mock recorder for MockSmsSender.
+type MockSmsSenderMockRecorder struct {
+	mock *MockSmsSender
+}
+
+// NewMockSmsSender creates a new mock instance.
+func NewMockSmsSender(ctrl *gomock.Controller) *MockSmsSender {
+	mock := &MockSmsSender{ctrl: ctrl}
+	mock.recorder = &MockSmsSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmsSender) EXPECT() *MockSmsSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockSmsSender) Send(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockSmsSenderMockRecorder) Send(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSmsSender)(nil).Send), arg0, arg1, arg2)
+}
@@ -5,9 +5,11 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
+	model "github.com/RipperAcskt/innotaxi/internal/model"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -34,30 +36,233 @@ func (m *MockTokenRepo) EXPECT() *MockTokenRepoMockRecorder {
 	return m.recorder
 }
 
+// AddFailedLogin mocks base method.
+func (m *MockTokenRepo) AddFailedLogin(arg0 context.Context, arg1 string, arg2 time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFailedLogin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFailedLogin indicates an expected call of AddFailedLogin.
+func (mr *MockTokenRepoMockRecorder) AddFailedLogin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFailedLogin", reflect.TypeOf((*MockTokenRepo)(nil).AddFailedLogin), arg0, arg1, arg2)
+}
+
+// AddOTP mocks base method.
+func (m *MockTokenRepo) AddOTP(arg0 context.Context, arg1, arg2, arg3 string, arg4 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOTP", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOTP indicates an expected call of AddOTP.
+func (mr *MockTokenRepoMockRecorder) AddOTP(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOTP", reflect.TypeOf((*MockTokenRepo)(nil).AddOTP), arg0, arg1, arg2, arg3, arg4)
+}
+
+// AddRTFamily mocks base method.
+func (m *MockTokenRepo) AddRTFamily(arg0 context.Context, arg1, arg2 string, arg3 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRTFamily", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRTFamily indicates an expected call of AddRTFamily.
+func (mr *MockTokenRepoMockRecorder) AddRTFamily(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRTFamily", reflect.TypeOf((*MockTokenRepo)(nil).AddRTFamily), arg0, arg1, arg2, arg3)
+}
+
+// AddSession mocks base method.
+func (m *MockTokenRepo) AddSession(arg0 context.Context, arg1 *model.Session, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockTokenRepoMockRecorder) AddSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockTokenRepo)(nil).AddSession), arg0, arg1, arg2)
+}
+
 // AddToken mocks base method.
-func (m *MockTokenRepo) AddToken(arg0 string, arg1 time.Duration) error {
+func (m *MockTokenRepo) AddToken(arg0 context.Context, arg1 string, arg2 time.Duration) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToken", arg0, arg1)
+	ret := m.ctrl.Call(m, "AddToken", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddToken indicates an expected call of AddToken.
-func (mr *MockTokenRepoMockRecorder) AddToken(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTokenRepoMockRecorder) AddToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToken", reflect.TypeOf((*MockTokenRepo)(nil).AddToken), arg0, arg1, arg2)
+}
+
+// CheckOTP mocks base method.
+func (m *MockTokenRepo) CheckOTP(arg0 context.Context, arg1, arg2 string, arg3 int) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOTP", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckOTP indicates an expected call of CheckOTP.
+func (mr *MockTokenRepoMockRecorder) CheckOTP(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOTP", reflect.TypeOf((*MockTokenRepo)(nil).CheckOTP), arg0, arg1, arg2, arg3)
+}
+
+// CheckSession mocks base method.
+func (m *MockTokenRepo) CheckSession(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSession", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSession indicates an expected call of CheckSession.
+func (mr *MockTokenRepoMockRecorder) CheckSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSession", reflect.TypeOf((*MockTokenRepo)(nil).CheckSession), arg0, arg1)
+}
+
+// DeleteFailedLogins mocks base method.
+func (m *MockTokenRepo) DeleteFailedLogins(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFailedLogins", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFailedLogins indicates an expected call of DeleteFailedLogins.
+func (mr *MockTokenRepoMockRecorder) DeleteFailedLogins(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToken", reflect.TypeOf((*MockTokenRepo)(nil).AddToken), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFailedLogins", reflect.TypeOf((*MockTokenRepo)(nil).DeleteFailedLogins), arg0, arg1)
+}
+
+// DeleteRTFamily mocks base method.
+func (m *MockTokenRepo) DeleteRTFamily(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRTFamily", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRTFamily indicates an expected call of DeleteRTFamily.
+func (mr *MockTokenRepoMockRecorder) DeleteRTFamily(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRTFamily", reflect.TypeOf((*MockTokenRepo)(nil).DeleteRTFamily), arg0, arg1)
+}
+
+// DeleteSessionById mocks base method.
+func (m *MockTokenRepo) DeleteSessionById(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionById indicates an expected call of DeleteSessionById.
+func (mr *MockTokenRepoMockRecorder) DeleteSessionById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionById", reflect.TypeOf((*MockTokenRepo)(nil).DeleteSessionById), arg0, arg1, arg2)
+}
+
+// DeleteSessionsByUserId mocks base method.
+func (m *MockTokenRepo) DeleteSessionsByUserId(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsByUserId", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsByUserId indicates an expected call of DeleteSessionsByUserId.
+func (mr *MockTokenRepoMockRecorder) DeleteSessionsByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsByUserId", reflect.TypeOf((*MockTokenRepo)(nil).DeleteSessionsByUserId), arg0, arg1)
+}
+
+// GetLoginLock mocks base method.
+func (m *MockTokenRepo) GetLoginLock(arg0 context.Context, arg1 string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginLock", arg0, arg1)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLock indicates an expected call of GetLoginLock.
+func (mr *MockTokenRepoMockRecorder) GetLoginLock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLock", reflect.TypeOf((*MockTokenRepo)(nil).GetLoginLock), arg0, arg1)
+}
+
+// GetSessionsByUserId mocks base method.
+func (m *MockTokenRepo) GetSessionsByUserId(arg0 context.Context, arg1 string) ([]*model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsByUserId", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionsByUserId indicates an expected call of GetSessionsByUserId.
+func (mr *MockTokenRepoMockRecorder) GetSessionsByUserId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsByUserId", reflect.TypeOf((*MockTokenRepo)(nil).GetSessionsByUserId), arg0, arg1)
 }
 
 // GetToken mocks base method.
-func (m *MockTokenRepo) GetToken(arg0 string) bool {
+func (m *MockTokenRepo) GetToken(arg0 context.Context, arg1 string) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetToken", arg0)
+	ret := m.ctrl.Call(m, "GetToken", arg0, arg1)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
 // GetToken indicates an expected call of GetToken.
-func (mr *MockTokenRepoMockRecorder) GetToken(arg0 interface{}) *gomock.Call {
+func (mr *MockTokenRepoMockRecorder) GetToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockTokenRepo)(nil).GetToken), arg0, arg1)
+}
+
+// LockLogin mocks base method.
+func (m *MockTokenRepo) LockLogin(arg0 context.Context, arg1 string, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLogin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockTokenRepoMockRecorder) LockLogin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockTokenRepo)(nil).LockLogin), arg0, arg1, arg2)
+}
+
+// RotateRT mocks base method.
+func (m *MockTokenRepo) RotateRT(arg0 context.Context, arg1, arg2, arg3 string, arg4 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRT", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRT indicates an expected call of RotateRT.
+func (mr *MockTokenRepoMockRecorder) RotateRT(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockTokenRepo)(nil).GetToken), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRT", reflect.TypeOf((*MockTokenRepo)(nil).RotateRT), arg0, arg1, arg2, arg3, arg4)
 }
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/model"
+)
+
+var (
+	ErrDriverNotFound    = NewError(CodeNotFound, "driver not found")
+	ErrUnknownTaxiType   = NewError(CodeInvalidArgument, "unknown taxi type")
+	ErrOrderNotFound     = NewError(CodeNotFound, "order not found")
+	ErrIncorrectRating   = NewError(CodeInvalidArgument, "rating must be from 1 to 5")
+	ErrRatingTimeExpired = NewError(CodeFailedPrecondition, "rating time expired")
+	ErrOrderAlreadyRated = NewError(CodeFailedPrecondition, "order already rated")
+	ErrOrderNotFinished  = NewError(CodeFailedPrecondition, "order is not finished")
+)
+
+type OrderCreate struct {
+	TaxiType string `json:"taxi_type" binding:"required"`
+	From     string `json:"from" binding:"required,max=255"`
+	To       string `json:"to" binding:"required,max=255"`
+}
+
+type OrderRating struct {
+	Rating int `json:"rating" binding:"required,min=1,max=5"`
+}
+
+type OrderRepo interface {
+	AddOrder(ctx context.Context, order *model.Order) error
+	GetOrdersByUserId(ctx context.Context, id string) ([]*model.Order, error)
+	GetLastOrderByUserId(ctx context.Context, id string) (*model.Order, error)
+	SetOrderRating(ctx context.Context, order *model.Order, rating int) error
+	FinishOrderByDriverId(ctx context.Context, driverID string) error
+	HasOrderInProgress(ctx context.Context, driverID, userID string) (bool, error)
+}
+
+// DriverRepo takes a free driver for the order and marks them busy. It
+// returns ErrDriverNotFound when there is no free driver of the taxi type.
+type DriverRepo interface {
+	FindFreeDriver(ctx context.Context, order *model.Order) (string, error)
+	// RateDriver passes the rating of the finished order to the driver
+	// service, which keeps the drivers' ratings.
+	RateDriver(ctx context.Context, order *model.Order, rating int) error
+	// ReleaseDriver frees the driver taken for the order which couldn't be
+	// stored and drops the order on the driver's side.
+	ReleaseDriver(ctx context.Context, order *model.Order) error
+}
+
+// releaseTimeout bounds the call which frees the driver of a failed order.
+const releaseTimeout = 5 * time.Second
+
+type OrderService struct {
+	OrderRepo
+	DriverRepo
+	queues map[string]*driverQueue
+	cfg    *config.Config
+}
+
+func NewOrderService(postgres OrderRepo, drivers DriverRepo, cfg *config.Config) *OrderService {
+	queues := make(map[string]*driverQueue, len(model.TaxiTypes))
+	for _, taxiType := range model.TaxiTypes {
+		queues[taxiType] = newDriverQueue(taxiType, drivers)
+	}
+	return &OrderService{postgres, drivers, queues, cfg}
+}
+
+func (s *OrderService) CreateOrder(ctx context.Context, userID string, order OrderCreate) (*model.Order, error) {
+	queue, ok := s.queues[order.TaxiType]
+	if !ok {
+		return nil, fmt.Errorf("taxi type: %v: %w", order.TaxiType, ErrUnknownTaxiType)
+	}
+
+	id, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse uint failed: %w", err)
+	}
+
+	res := &model.Order{
+		UserID:   id,
+		TaxiType: order.TaxiType,
+		From:     order.From,
+		To:       order.To,
+		Status:   model.OrderStatusInProgress,
+	}
+
+	res.DriverID, err = queue.wait(ctx, res, time.Duration(s.cfg.DRIVER_WAIT_TIME)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("wait for driver failed: %w", err)
+	}
+
+	res.Date = time.Now().UTC()
+	err = s.AddOrder(ctx, res)
+	if err != nil {
+		// The driver is busy with the order already. The request's context
+		// may be cancelled, so the driver is freed with a context of its own.
+		releaseCtx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+		defer cancel()
+
+		releaseErr := s.ReleaseDriver(releaseCtx, res)
+		if releaseErr != nil {
+			return nil, fmt.Errorf("add order failed: %w, release driver failed: %v", err, releaseErr)
+		}
+		return nil, fmt.Errorf("add order failed: %w", err)
+	}
+	return res, nil
+}
+
+func (s *OrderService) GetOrders(ctx context.Context, userID string) ([]*model.Order, error) {
+	return s.GetOrdersByUserId(ctx, userID)
+}
+
+// IsPassenger reports whether the user is riding with the driver now, that
+// is whether they have an order in progress together.
+func (s *OrderService) IsPassenger(ctx context.Context, driverID, userID string) (bool, error) {
+	return s.HasOrderInProgress(ctx, driverID, userID)
+}
+
+// RateLastOrder rates the last order of the user. It can be rated once, after
+// it's finished and for RATING_TIME minutes from then.
+func (s *OrderService) RateLastOrder(ctx context.Context, userID string, rating OrderRating) error {
+	if rating.Rating < 1 || rating.Rating > 5 {
+		return ErrIncorrectRating
+	}
+
+	order, err := s.GetLastOrderByUserId(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get last order by user id failed: %w", err)
+	}
+
+	if order.Status != model.OrderStatusFinished {
+		return ErrOrderNotFinished
+	}
+	// Orders finished before the finish time was recorded are long out of
+	// the window.
+	if order.FinishedAt == nil || time.Since(*order.FinishedAt) > time.Duration(s.cfg.RATING_TIME)*time.Minute {
+		return ErrRatingTimeExpired
+	}
+	if order.Rating != 0 {
+		return ErrOrderAlreadyRated
+	}
+
+	// The driver is rated first, so the order stays unrated and can be rated
+	// again if the call fails.
+	err = s.RateDriver(ctx, order, rating.Rating)
+	if err != nil {
+		return fmt.Errorf("rate driver failed: %w", err)
+	}
+
+	return s.SetOrderRating(ctx, order, rating.Rating)
+}
+
+// DriverReleased finishes the driver's order and wakes up users waiting for a
+// driver of the same taxi type.
+func (s *OrderService) DriverReleased(ctx context.Context, driverID, taxiType string) error {
+	err := s.FinishOrderByDriverId(ctx, driverID)
+	if err != nil {
+		return fmt.Errorf("finish order by driver id failed: %w", err)
+	}
+
+	if queue, ok := s.queues[taxiType]; ok {
+		queue.notify()
+	}
+	return nil
+}
+
+// WakeUpQueues makes the head of every queue search for a driver again. It is
+// used when drivers could have become free unnoticed, e.g. after reconnecting
+// to the driver service.
+func (s *OrderService) WakeUpQueues() {
+	for _, queue := range s.queues {
+		queue.notify()
+	}
+}
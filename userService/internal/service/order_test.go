@@ -0,0 +1,315 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/RipperAcskt/innotaxi/internal/service/mocks"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCreateOrder(t *testing.T) {
+	type mockBehavior func(s *mocks.MockDriverRepo, o *mocks.MockOrderRepo)
+	errAdd := fmt.Errorf("exec context failed")
+	test := []struct {
+		name         string
+		order        service.OrderCreate
+		mockBehavior mockBehavior
+		driverID     string
+		err          error
+	}{
+		{
+			name: "driver found",
+			order: service.OrderCreate{
+				TaxiType: model.TaxiTypeEconomy,
+				From:     "a",
+				To:       "b",
+			},
+			mockBehavior: func(s *mocks.MockDriverRepo, o *mocks.MockOrderRepo) {
+				s.EXPECT().FindFreeDriver(gomock.Any(), gomock.Any()).Return("1", nil)
+				o.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			driverID: "1",
+			err:      nil,
+		},
+		{
+			name: "add order failed",
+			order: service.OrderCreate{
+				TaxiType: model.TaxiTypeEconomy,
+				From:     "a",
+				To:       "b",
+			},
+			mockBehavior: func(s *mocks.MockDriverRepo, o *mocks.MockOrderRepo) {
+				s.EXPECT().FindFreeDriver(gomock.Any(), gomock.Any()).Return("1", nil)
+				o.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(errAdd)
+				s.EXPECT().ReleaseDriver(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, order *model.Order) error {
+					assert.Equal(t, ctx.Err(), nil)
+					assert.Equal(t, order.DriverID, "1")
+					assert.Equal(t, order.UserID, uint64(1))
+					return nil
+				})
+			},
+			driverID: "",
+			err:      errAdd,
+		},
+		{
+			name: "release driver failed",
+			order: service.OrderCreate{
+				TaxiType: model.TaxiTypeEconomy,
+				From:     "a",
+				To:       "b",
+			},
+			mockBehavior: func(s *mocks.MockDriverRepo, o *mocks.MockOrderRepo) {
+				s.EXPECT().FindFreeDriver(gomock.Any(), gomock.Any()).Return("1", nil)
+				o.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(errAdd)
+				s.EXPECT().ReleaseDriver(gomock.Any(), gomock.Any()).Return(fmt.Errorf("unavailable"))
+			},
+			driverID: "",
+			err:      errAdd,
+		},
+		{
+			name: "driver not found",
+			order: service.OrderCreate{
+				TaxiType: model.TaxiTypeComfort,
+				From:     "a",
+				To:       "b",
+			},
+			mockBehavior: func(s *mocks.MockDriverRepo, o *mocks.MockOrderRepo) {
+				s.EXPECT().FindFreeDriver(gomock.Any(), gomock.Any()).Return("", service.ErrDriverNotFound).AnyTimes()
+			},
+			driverID: "",
+			err:      service.ErrDriverNotFound,
+		},
+		{
+			name: "unknown taxi type",
+			order: service.OrderCreate{
+				TaxiType: "bus",
+				From:     "a",
+				To:       "b",
+			},
+			mockBehavior: func(s *mocks.MockDriverRepo, o *mocks.MockOrderRepo) {},
+			driverID:     "",
+			err:          service.ErrUnknownTaxiType,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			driverRepo := mocks.NewMockDriverRepo(ctrl)
+			orderRepo := mocks.NewMockOrderRepo(ctrl)
+			tt.mockBehavior(driverRepo, orderRepo)
+
+			service := service.Service{
+				OrderService: service.NewOrderService(orderRepo, driverRepo, &config.Config{DRIVER_WAIT_TIME: 1}),
+			}
+
+			order, err := service.CreateOrder(context.Background(), "1", tt.order)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			if tt.err == nil {
+				assert.Equal(t, order.DriverID, tt.driverID)
+				assert.Equal(t, order.Status, model.OrderStatusInProgress)
+			}
+		})
+	}
+}
+
+func TestCreateOrderQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	driverRepo := mocks.NewMockDriverRepo(ctrl)
+	orderRepo := mocks.NewMockOrderRepo(ctrl)
+	orderRepo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(nil)
+	orderRepo.EXPECT().FinishOrderByDriverId(gomock.Any(), "2").Return(nil)
+	gomock.InOrder(
+		driverRepo.EXPECT().FindFreeDriver(gomock.Any(), gomock.Any()).Return("", service.ErrDriverNotFound),
+		driverRepo.EXPECT().FindFreeDriver(gomock.Any(), gomock.Any()).Return("1", nil),
+		driverRepo.EXPECT().FindFreeDriver(gomock.Any(), gomock.Any()).Return("", service.ErrDriverNotFound).AnyTimes(),
+	)
+
+	s := service.NewOrderService(orderRepo, driverRepo, &config.Config{DRIVER_WAIT_TIME: 2})
+	order := service.OrderCreate{
+		TaxiType: model.TaxiTypeBusiness,
+		From:     "a",
+		To:       "b",
+	}
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		users []string
+		errs  []error
+	)
+	for _, id := range []string{"1", "2"} {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			_, err := s.CreateOrder(context.Background(), id, order)
+
+			mu.Lock()
+			defer mu.Unlock()
+			users = append(users, id)
+			errs = append(errs, err)
+		}(id)
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	err := s.DriverReleased(context.Background(), "2", model.TaxiTypeBusiness)
+	assert.Equal(t, err, nil)
+	wg.Wait()
+
+	assert.Equal(t, users, []string{"1", "2"})
+	assert.Equal(t, errs[0], nil)
+	assert.Equal(t, errors.Is(errs[1], service.ErrDriverNotFound), true)
+}
+
+func TestGetOrders(t *testing.T) {
+	type mockBehavior func(s *mocks.MockOrderRepo)
+	test := []struct {
+		name         string
+		mockBehavior mockBehavior
+		orders       int
+		err          error
+	}{
+		{
+			name: "get orders",
+			mockBehavior: func(s *mocks.MockOrderRepo) {
+				s.EXPECT().GetOrdersByUserId(context.Background(), "1").Return([]*model.Order{
+					{
+						ID:       1,
+						UserID:   1,
+						DriverID: "1",
+						TaxiType: model.TaxiTypeEconomy,
+						From:     "a",
+						To:       "b",
+						Status:   model.OrderStatusFinished,
+					},
+				}, nil)
+			},
+			orders: 1,
+			err:    nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mocks.NewMockOrderRepo(ctrl)
+			tt.mockBehavior(orderRepo)
+
+			service := service.Service{
+				OrderService: service.NewOrderService(orderRepo, mocks.NewMockDriverRepo(ctrl), &config.Config{}),
+			}
+
+			orders, err := service.GetOrders(context.Background(), "1")
+			assert.Equal(t, err, tt.err)
+			assert.Equal(t, len(orders), tt.orders)
+		})
+	}
+}
+
+func TestRateLastOrder(t *testing.T) {
+	type mockBehavior func(s *mocks.MockOrderRepo, d *mocks.MockDriverRepo)
+
+	// The rating window is counted from the finish, not from the order.
+	finished := time.Now().Add(-time.Minute)
+	test := []struct {
+		name         string
+		rating       int
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:   "rate order",
+			rating: 5,
+			mockBehavior: func(s *mocks.MockOrderRepo, d *mocks.MockDriverRepo) {
+				order := &model.Order{ID: 1, UserID: 1, Date: time.Now().Add(-2 * time.Hour), Status: model.OrderStatusFinished, FinishedAt: &finished}
+				s.EXPECT().GetLastOrderByUserId(context.Background(), "1").Return(order, nil)
+				d.EXPECT().RateDriver(context.Background(), order, 5).Return(nil)
+				s.EXPECT().SetOrderRating(context.Background(), order, 5).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:   "rate driver failed",
+			rating: 5,
+			mockBehavior: func(s *mocks.MockOrderRepo, d *mocks.MockDriverRepo) {
+				order := &model.Order{ID: 1, UserID: 1, Date: time.Now(), Status: model.OrderStatusFinished, FinishedAt: &finished}
+				s.EXPECT().GetLastOrderByUserId(context.Background(), "1").Return(order, nil)
+				d.EXPECT().RateDriver(context.Background(), order, 5).Return(service.ErrOrderNotFound)
+			},
+			err: service.ErrOrderNotFound,
+		},
+		{
+			name:         "incorrect rating",
+			rating:       6,
+			mockBehavior: func(s *mocks.MockOrderRepo, d *mocks.MockDriverRepo) {},
+			err:          service.ErrIncorrectRating,
+		},
+		{
+			name:   "rating time expired",
+			rating: 4,
+			mockBehavior: func(s *mocks.MockOrderRepo, d *mocks.MockDriverRepo) {
+				expired := time.Now().Add(-2 * time.Hour)
+				s.EXPECT().GetLastOrderByUserId(context.Background(), "1").Return(&model.Order{ID: 1, UserID: 1, Date: expired, Status: model.OrderStatusFinished, FinishedAt: &expired}, nil)
+			},
+			err: service.ErrRatingTimeExpired,
+		},
+		{
+			name:   "order in progress",
+			rating: 4,
+			mockBehavior: func(s *mocks.MockOrderRepo, d *mocks.MockDriverRepo) {
+				s.EXPECT().GetLastOrderByUserId(context.Background(), "1").Return(&model.Order{ID: 1, UserID: 1, Date: time.Now(), Status: model.OrderStatusInProgress}, nil)
+			},
+			err: service.ErrOrderNotFinished,
+		},
+		{
+			name:   "order already rated",
+			rating: 4,
+			mockBehavior: func(s *mocks.MockOrderRepo, d *mocks.MockDriverRepo) {
+				s.EXPECT().GetLastOrderByUserId(context.Background(), "1").Return(&model.Order{ID: 1, UserID: 1, Date: time.Now(), Status: model.OrderStatusFinished, FinishedAt: &finished, Rating: 3}, nil)
+			},
+			err: service.ErrOrderAlreadyRated,
+		},
+		{
+			name:   "no orders",
+			rating: 4,
+			mockBehavior: func(s *mocks.MockOrderRepo, d *mocks.MockDriverRepo) {
+				s.EXPECT().GetLastOrderByUserId(context.Background(), "1").Return(nil, service.ErrOrderNotFound)
+			},
+			err: service.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mocks.NewMockOrderRepo(ctrl)
+			driverRepo := mocks.NewMockDriverRepo(ctrl)
+			tt.mockBehavior(orderRepo, driverRepo)
+
+			rating := service.OrderRating{Rating: tt.rating}
+			service := service.Service{
+				OrderService: service.NewOrderService(orderRepo, driverRepo, &config.Config{RATING_TIME: 60}),
+			}
+
+			err := service.RateLastOrder(context.Background(), "1", rating)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/config"
+)
+
+const (
+	otpDigits = 6
+
+	defaultOTPExp      = 5
+	defaultOTPAttempts = 5
+)
+
+var (
+	ErrOTPNotFound     = NewError(CodeInvalidArgument, "code expired or wasn't sent")
+	ErrWrongOTP        = NewError(CodeInvalidArgument, "wrong code")
+	ErrTooManyOTPTries = NewError(CodeResourceExhausted, "too many attempts")
+)
+
+type PhoneVerify struct {
+	PhoneNumber string `json:"phone_number" binding:"required,phone"`
+	Code        string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// SmsSender sends text messages to phone numbers.
+type SmsSender interface {
+	Send(ctx context.Context, phone, text string) error
+}
+
+// OTPRepo stores one-time codes with the value they confirm, the phone
+// number on sign up or the new email or phone number of a user.
+type OTPRepo interface {
+	AddOTP(ctx context.Context, key, code, value string, expired time.Duration) error
+	// CheckOTP counts the attempt and returns the value if code is the one
+	// stored under key. The code is deleted once it matches or attempts are
+	// used up, then ErrOTPNotFound and ErrTooManyOTPTries are returned.
+	CheckOTP(ctx context.Context, key, code string, attempts int) (value string, ok bool, err error)
+}
+
+// otp issues the codes and checks them with the limits of the config.
+type otp struct {
+	repo OTPRepo
+	cfg  *config.Config
+}
+
+func (o otp) exp() int {
+	if o.cfg.OTP_EXP == 0 {
+		return defaultOTPExp
+	}
+	return o.cfg.OTP_EXP
+}
+
+// issue stores a new code for value under key, the previous one is replaced.
+func (o otp) issue(ctx context.Context, key, value string) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(otpDigits), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("int failed: %w", err)
+	}
+	code := fmt.Sprintf("%0*d", otpDigits, n)
+
+	err = o.repo.AddOTP(ctx, key, code, value, time.Duration(o.exp())*time.Minute)
+	if err != nil {
+		return "", fmt.Errorf("add otp failed: %w", err)
+	}
+	return code, nil
+}
+
+// issueToken is issue with a random token instead of a short code, for
+// links and tokens which live longer than a code.
+func (o otp) issueToken(ctx context.Context, key, value string, expired time.Duration) (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", fmt.Errorf("read failed: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(token)
+
+	err = o.repo.AddOTP(ctx, key, code, value, expired)
+	if err != nil {
+		return "", fmt.Errorf("add otp failed: %w", err)
+	}
+	return code, nil
+}
+
+// check returns the value the code was issued for.
+func (o otp) check(ctx context.Context, key, code string) (string, error) {
+	attempts := o.cfg.OTP_ATTEMPTS
+	if attempts == 0 {
+		attempts = defaultOTPAttempts
+	}
+
+	value, ok, err := o.repo.CheckOTP(ctx, key, code, attempts)
+	if err != nil {
+		return "", fmt.Errorf("check otp failed: %w", err)
+	}
+	if !ok {
+		return "", ErrWrongOTP
+	}
+	return value, nil
+}
+
+func signUpKey(phone string) string {
+	return "sign-up:" + phone
+}
+
+// SendOTP sends a new code to the phone number, the previous one is replaced.
+func (s *AuthService) SendOTP(ctx context.Context, phone string) error {
+	code, err := s.otp.issue(ctx, signUpKey(phone), phone)
+	if err != nil {
+		return fmt.Errorf("issue failed: %w", err)
+	}
+
+	err = s.sms.Send(ctx, phone, fmt.Sprintf("InnoTaxi code: %s. It expires in %d minutes.", code, s.otp.exp()))
+	if err != nil {
+		return fmt.Errorf("send failed: %w", err)
+	}
+	return nil
+}
+
+// VerifyPhone checks the code sent on sign up and activates the user.
+func (s *AuthService) VerifyPhone(ctx context.Context, verify PhoneVerify) error {
+	verify.PhoneNumber = NormalizePhone(verify.PhoneNumber)
+
+	phone, err := s.otp.check(ctx, signUpKey(verify.PhoneNumber), verify.Code)
+	if err != nil {
+		return fmt.Errorf("check failed: %w", err)
+	}
+
+	err = s.ActivateUserByPhoneNumber(ctx, phone)
+	if err != nil {
+		return fmt.Errorf("activate user by phone number failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/notify"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/RipperAcskt/innotaxi/internal/service/mocks"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+)
+
+func TestVerifyPhone(t *testing.T) {
+	type mockBehavior func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, verify service.PhoneVerify)
+	type fileds struct {
+		authRepo  *mocks.MockAuthRepo
+		tokenRepo *mocks.MockTokenRepo
+	}
+	test := []struct {
+		name         string
+		verify       service.PhoneVerify
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:   "correct code",
+			verify: service.PhoneVerify{PhoneNumber: "+7455456", Code: "123456"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, verify service.PhoneVerify) {
+				r.EXPECT().CheckOTP(context.Background(), "sign-up:"+verify.PhoneNumber, verify.Code, 3).Return(verify.PhoneNumber, true, nil)
+				s.EXPECT().ActivateUserByPhoneNumber(context.Background(), verify.PhoneNumber).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:   "wrong code",
+			verify: service.PhoneVerify{PhoneNumber: "+7455456", Code: "654321"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, verify service.PhoneVerify) {
+				r.EXPECT().CheckOTP(context.Background(), "sign-up:"+verify.PhoneNumber, verify.Code, 3).Return("", false, nil)
+			},
+			err: service.ErrWrongOTP,
+		},
+		{
+			name:   "attempts used up",
+			verify: service.PhoneVerify{PhoneNumber: "+7455456", Code: "654321"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, verify service.PhoneVerify) {
+				r.EXPECT().CheckOTP(context.Background(), "sign-up:"+verify.PhoneNumber, verify.Code, 3).Return("", false, service.ErrTooManyOTPTries)
+			},
+			err: service.ErrTooManyOTPTries,
+		},
+		{
+			name:   "user already verified",
+			verify: service.PhoneVerify{PhoneNumber: "+7455456", Code: "123456"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, verify service.PhoneVerify) {
+				r.EXPECT().CheckOTP(context.Background(), "sign-up:"+verify.PhoneNumber, verify.Code, 3).Return(verify.PhoneNumber, true, nil)
+				s.EXPECT().ActivateUserByPhoneNumber(context.Background(), verify.PhoneNumber).Return(service.ErrUserDoesNotExists)
+			},
+			err: service.ErrUserDoesNotExists,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fileds{
+				authRepo:  mocks.NewMockAuthRepo(ctrl),
+				tokenRepo: mocks.NewMockTokenRepo(ctrl),
+			}
+			authService := service.NewAuthSevice(f.authRepo, f.tokenRepo, mocks.NewMockSmsSender(ctrl), notify.NewMemoryMailer(), hs256Keys, "124jkhsdaf3425", &config.Config{OTP_ATTEMPTS: 3})
+
+			tt.mockBehavior(f.authRepo, f.tokenRepo, tt.verify)
+
+			service := service.Service{
+				AuthService: authService,
+			}
+
+			err := service.VerifyPhone(context.Background(), tt.verify)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
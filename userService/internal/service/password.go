@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultResetTokenExp = 15
+
+var ErrBadResetToken = NewError(CodeInvalidArgument, "bad reset token")
+
+// PasswordForgot is the phone number or the email the reset token is sent to.
+type PasswordForgot struct {
+	PhoneNumber string `json:"phone_number" binding:"required_without=Email,omitempty,phone"`
+	Email       string `json:"email" binding:"required_without=PhoneNumber,omitempty,mail"`
+}
+
+type PasswordReset struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,password"`
+}
+
+type PasswordChange struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,password"`
+}
+
+func resetKey(id uint64) string {
+	return fmt.Sprintf("reset:%d", id)
+}
+
+// ForgotPassword sends a reset token to the user of the phone number or the
+// email. Unknown users are ignored, so the caller can't find out who has an
+// account.
+func (s *AuthService) ForgotPassword(ctx context.Context, forgot PasswordForgot) error {
+	forgot.PhoneNumber = NormalizePhone(forgot.PhoneNumber)
+	forgot.Email = NormalizeEmail(forgot.Email)
+
+	var (
+		user *UserSingIn
+		err  error
+	)
+	switch {
+	case forgot.PhoneNumber != "":
+		user, err = s.CheckUserByPhoneNumber(ctx, forgot.PhoneNumber)
+	case forgot.Email != "":
+		user, err = s.CheckUserByEmail(ctx, forgot.Email)
+	default:
+		return fmt.Errorf("phone number or email required: %w", ErrBadResetToken)
+	}
+	if err != nil {
+		if errors.Is(err, ErrUserDoesNotExists) {
+			return nil
+		}
+		return fmt.Errorf("check user failed: %w", err)
+	}
+
+	exp := s.cfg.RESET_TOKEN_EXP
+	if exp == 0 {
+		exp = defaultResetTokenExp
+	}
+	secret, err := s.otp.issueToken(ctx, resetKey(user.ID), fmt.Sprint(user.ID), time.Duration(exp)*time.Minute)
+	if err != nil {
+		return fmt.Errorf("issue token failed: %w", err)
+	}
+
+	// The token carries the user's id, so it can be checked without the
+	// phone number or the email.
+	token := fmt.Sprintf("%d.%s", user.ID, secret)
+	text := fmt.Sprintf("InnoTaxi password reset token: %s. It expires in %d minutes.", token, exp)
+
+	if forgot.PhoneNumber != "" {
+		err = s.sms.Send(ctx, forgot.PhoneNumber, text)
+	} else {
+		err = s.mailer.Send(ctx, forgot.Email, "Reset your password", text)
+	}
+	if err != nil {
+		return fmt.Errorf("send failed: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword sets the password of the reset token's user and signs the
+// user out on every device.
+func (s *AuthService) ResetPassword(ctx context.Context, reset PasswordReset) error {
+	idPart, secret, ok := strings.Cut(reset.Token, ".")
+	if !ok {
+		return ErrBadResetToken
+	}
+	id, err := strconv.ParseUint(idPart, 10, 64)
+	if err != nil {
+		return ErrBadResetToken
+	}
+
+	_, err = s.otp.check(ctx, resetKey(id), secret)
+	if err != nil {
+		if errors.Is(err, ErrWrongOTP) || errors.Is(err, ErrOTPNotFound) {
+			return fmt.Errorf("%v: %w", err, ErrBadResetToken)
+		}
+		return fmt.Errorf("check failed: %w", err)
+	}
+
+	return s.setPassword(ctx, id, reset.Password)
+}
+
+// ChangePassword replaces the password if the old one is correct and signs
+// the user out on every device.
+func (s *AuthService) ChangePassword(ctx context.Context, id string, change PasswordChange) error {
+	userID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse uint failed: %w", err)
+	}
+
+	hash, err := s.GetPasswordById(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get password by id failed: %w", err)
+	}
+
+	ok, _, err := s.ComparePassword(hash, change.OldPassword)
+	if err != nil {
+		return fmt.Errorf("compare password failed: %w", err)
+	}
+	if !ok {
+		return ErrIncorrectPassword
+	}
+
+	return s.setPassword(ctx, userID, change.NewPassword)
+}
+
+func (s *AuthService) setPassword(ctx context.Context, id uint64, password string) error {
+	hash, err := s.GenerateHash(password)
+	if err != nil {
+		return fmt.Errorf("generate hash failed: %w", err)
+	}
+
+	err = s.UpdatePasswordById(ctx, id, hash)
+	if err != nil {
+		return fmt.Errorf("update password by id failed: %w", err)
+	}
+
+	err = s.DeleteSessionsByUserId(ctx, fmt.Sprint(id))
+	if err != nil {
+		return fmt.Errorf("delete sessions by user id failed: %w", err)
+	}
+	return nil
+}
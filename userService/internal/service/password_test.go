@@ -0,0 +1,217 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/notify"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/RipperAcskt/innotaxi/internal/service/mocks"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+)
+
+func TestForgotPassword(t *testing.T) {
+	type mockBehavior func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, sms *mocks.MockSmsSender)
+	type fileds struct {
+		authRepo  *mocks.MockAuthRepo
+		tokenRepo *mocks.MockTokenRepo
+		sms       *mocks.MockSmsSender
+	}
+	test := []struct {
+		name         string
+		forgot       service.PasswordForgot
+		mockBehavior mockBehavior
+		mails        int
+		err          error
+	}{
+		{
+			name:   "by phone number",
+			forgot: service.PasswordForgot{PhoneNumber: "+7455456"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, sms *mocks.MockSmsSender) {
+				s.EXPECT().CheckUserByPhoneNumber(context.Background(), "+7455456").Return(&service.UserSingIn{ID: 9}, nil)
+				r.EXPECT().AddOTP(context.Background(), "reset:9", gomock.Any(), "9", 15*time.Minute).Return(nil)
+				sms.EXPECT().Send(context.Background(), "+7455456", gomock.Any()).DoAndReturn(func(ctx context.Context, phone, text string) error {
+					assert.Equal(t, strings.Contains(text, "token: 9."), true)
+					return nil
+				})
+			},
+			mails: 0,
+			err:   nil,
+		},
+		{
+			name:   "by email",
+			forgot: service.PasswordForgot{Email: "ripper@mail.ru"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, sms *mocks.MockSmsSender) {
+				s.EXPECT().CheckUserByEmail(context.Background(), "ripper@mail.ru").Return(&service.UserSingIn{ID: 9}, nil)
+				r.EXPECT().AddOTP(context.Background(), "reset:9", gomock.Any(), "9", 15*time.Minute).Return(nil)
+			},
+			mails: 1,
+			err:   nil,
+		},
+		{
+			name:   "unknown user",
+			forgot: service.PasswordForgot{Email: "ivan@mail.ru"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, sms *mocks.MockSmsSender) {
+				s.EXPECT().CheckUserByEmail(context.Background(), "ivan@mail.ru").Return(nil, service.ErrUserDoesNotExists)
+			},
+			mails: 0,
+			err:   nil,
+		},
+		{
+			name:         "nothing to send to",
+			forgot:       service.PasswordForgot{},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo, sms *mocks.MockSmsSender) {},
+			mails:        0,
+			err:          service.ErrBadResetToken,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fileds{
+				authRepo:  mocks.NewMockAuthRepo(ctrl),
+				tokenRepo: mocks.NewMockTokenRepo(ctrl),
+				sms:       mocks.NewMockSmsSender(ctrl),
+			}
+			mailer := notify.NewMemoryMailer()
+			authService := service.NewAuthSevice(f.authRepo, f.tokenRepo, f.sms, mailer, hs256Keys, "124jkhsdaf3425", &config.Config{})
+
+			tt.mockBehavior(f.authRepo, f.tokenRepo, f.sms)
+
+			service := service.Service{
+				AuthService: authService,
+			}
+
+			err := service.ForgotPassword(context.Background(), tt.forgot)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			assert.Equal(t, len(mailer.Mails()), tt.mails)
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	type mockBehavior func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo)
+	type fileds struct {
+		authRepo  *mocks.MockAuthRepo
+		tokenRepo *mocks.MockTokenRepo
+	}
+	test := []struct {
+		name         string
+		reset        service.PasswordReset
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:  "reset password",
+			reset: service.PasswordReset{Token: "9.secret", Password: "54321"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo) {
+				r.EXPECT().CheckOTP(context.Background(), "reset:9", "secret", 5).Return("9", true, nil)
+				s.EXPECT().UpdatePasswordById(context.Background(), uint64(9), gomock.Any()).Return(nil)
+				r.EXPECT().DeleteSessionsByUserId(context.Background(), "9").Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:  "wrong secret",
+			reset: service.PasswordReset{Token: "9.guess", Password: "54321"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo) {
+				r.EXPECT().CheckOTP(context.Background(), "reset:9", "guess", 5).Return("", false, nil)
+			},
+			err: service.ErrBadResetToken,
+		},
+		{
+			name:         "malformed token",
+			reset:        service.PasswordReset{Token: "secret", Password: "54321"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo) {},
+			err:          service.ErrBadResetToken,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fileds{
+				authRepo:  mocks.NewMockAuthRepo(ctrl),
+				tokenRepo: mocks.NewMockTokenRepo(ctrl),
+			}
+			authService := service.NewAuthSevice(f.authRepo, f.tokenRepo, mocks.NewMockSmsSender(ctrl), notify.NewMemoryMailer(), hs256Keys, "124jkhsdaf3425", &config.Config{})
+
+			tt.mockBehavior(f.authRepo, f.tokenRepo)
+
+			service := service.Service{
+				AuthService: authService,
+			}
+
+			err := service.ResetPassword(context.Background(), tt.reset)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	type mockBehavior func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo)
+	type fileds struct {
+		authRepo  *mocks.MockAuthRepo
+		tokenRepo *mocks.MockTokenRepo
+	}
+
+	hash, _ := service.NewArgon2idHasher().Hash("12345")
+
+	test := []struct {
+		name         string
+		change       service.PasswordChange
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:   "change password",
+			change: service.PasswordChange{OldPassword: "12345", NewPassword: "54321"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo) {
+				s.EXPECT().GetPasswordById(context.Background(), uint64(9)).Return(hash, nil)
+				s.EXPECT().UpdatePasswordById(context.Background(), uint64(9), gomock.Any()).Return(nil)
+				r.EXPECT().DeleteSessionsByUserId(context.Background(), "9").Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:   "wrong old password",
+			change: service.PasswordChange{OldPassword: "11111", NewPassword: "54321"},
+			mockBehavior: func(s *mocks.MockAuthRepo, r *mocks.MockTokenRepo) {
+				s.EXPECT().GetPasswordById(context.Background(), uint64(9)).Return(hash, nil)
+			},
+			err: service.ErrIncorrectPassword,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fileds{
+				authRepo:  mocks.NewMockAuthRepo(ctrl),
+				tokenRepo: mocks.NewMockTokenRepo(ctrl),
+			}
+			authService := service.NewAuthSevice(f.authRepo, f.tokenRepo, mocks.NewMockSmsSender(ctrl), notify.NewMemoryMailer(), hs256Keys, "124jkhsdaf3425", &config.Config{})
+
+			tt.mockBehavior(f.authRepo, f.tokenRepo)
+
+			service := service.Service{
+				AuthService: authService,
+			}
+
+			err := service.ChangePassword(context.Background(), "9", tt.change)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
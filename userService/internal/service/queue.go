@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+)
+
+const queueSize = 1024
+
+var ErrQueueIsFull = NewError(CodeResourceExhausted, "queue is full")
+
+type waiter struct {
+	ctx      context.Context
+	order    *model.Order
+	deadline time.Time
+	result   chan driverResult
+}
+
+type driverResult struct {
+	driverID string
+	err      error
+}
+
+// driverQueue serves users waiting for a driver of one taxi type strictly in
+// the order they came: only the head of the queue searches for a driver, so the
+// first user is always the first to get a driver or to be refused. The head
+// searches again only when it's notified that a driver became free.
+type driverQueue struct {
+	taxiType string
+	drivers  DriverRepo
+	waiters  chan *waiter
+	free     chan struct{}
+}
+
+func newDriverQueue(taxiType string, drivers DriverRepo) *driverQueue {
+	q := &driverQueue{
+		taxiType: taxiType,
+		drivers:  drivers,
+		waiters:  make(chan *waiter, queueSize),
+		free:     make(chan struct{}, 1),
+	}
+	go q.run()
+	return q
+}
+
+func (q *driverQueue) wait(ctx context.Context, order *model.Order, timeout time.Duration) (string, error) {
+	w := &waiter{
+		ctx:      ctx,
+		order:    order,
+		deadline: time.Now().Add(timeout),
+		result:   make(chan driverResult, 1),
+	}
+
+	select {
+	case q.waiters <- w:
+	default:
+		return "", ErrQueueIsFull
+	}
+
+	res := <-w.result
+	return res.driverID, res.err
+}
+
+// notify wakes the head of the queue up to search for a driver again.
+func (q *driverQueue) notify() {
+	select {
+	case q.free <- struct{}{}:
+	default:
+	}
+}
+
+func (q *driverQueue) run() {
+	for w := range q.waiters {
+		id, err := q.serve(w)
+		w.result <- driverResult{id, err}
+	}
+}
+
+func (q *driverQueue) serve(w *waiter) (string, error) {
+	timer := time.NewTimer(time.Until(w.deadline))
+	defer timer.Stop()
+
+	for {
+		if err := w.ctx.Err(); err != nil {
+			return "", err
+		}
+
+		id, err := q.drivers.FindFreeDriver(w.ctx, w.order)
+		if err == nil {
+			return id, nil
+		}
+		if !errors.Is(err, ErrDriverNotFound) {
+			return "", err
+		}
+
+		select {
+		case <-w.ctx.Done():
+			return "", w.ctx.Err()
+		case <-timer.C:
+			return "", ErrDriverNotFound
+		case <-q.free:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+)
+
+// Scopes routes require. A scope lets the role act on the resources of the
+// token's subject, the scope with the AnyUser suffix on the ones of every user
+// and the scope with the Passenger suffix on the ones of the users riding
+// with the driver.
+const (
+	ScopeProfileRead   = "profile:read"
+	ScopeProfileWrite  = "profile:write"
+	ScopeProfileDelete = "profile:delete"
+	ScopeOrdersRead    = "orders:read"
+	ScopeOrdersWrite   = "orders:write"
+	ScopeSessions      = "sessions"
+	ScopePassword      = "password"
+	ScopeUsersList     = "users:list"
+	ScopeUsersRestore  = "users:restore"
+	ScopeLogsRead      = "logs:read"
+
+	AnyUser   = ":any"
+	Passenger = ":passenger"
+)
+
+var ErrForbidden = NewError(CodePermissionDenied, "forbidden")
+
+var roleScopes = map[string]map[string]bool{
+	User: {
+		ScopeProfileRead:   true,
+		ScopeProfileWrite:  true,
+		ScopeProfileDelete: true,
+		ScopeOrdersRead:    true,
+		ScopeOrdersWrite:   true,
+		ScopeSessions:      true,
+		ScopePassword:      true,
+	},
+	// Drivers see the profiles of the users they drive now, they have no
+	// resources of their own here.
+	Driver: {
+		ScopeProfileRead + Passenger: true,
+	},
+	Admin: {
+		ScopeProfileRead:             true,
+		ScopeProfileWrite:            true,
+		ScopeProfileDelete:           true,
+		ScopeOrdersRead:              true,
+		ScopeOrdersWrite:             true,
+		ScopeSessions:                true,
+		ScopePassword:                true,
+		ScopeProfileRead + AnyUser:   true,
+		ScopeProfileWrite + AnyUser:  true,
+		ScopeProfileDelete + AnyUser: true,
+		ScopeUsersList:               true,
+		ScopeUsersRestore + AnyUser:  true,
+		ScopeLogsRead:                true,
+	},
+}
+
+// HasScope reports whether the role is granted the scope.
+func HasScope(role, scope string) bool {
+	return roleScopes[role][scope]
+}
+
+// Authorize checks that the token's role has every scope for the resources
+// of owner, the user id of the request path. An empty owner means the
+// resources of the token's subject. passenger tells whether owner is riding
+// with the driver of the token now.
+func Authorize(claims *AccessClaims, owner string, passenger bool, scopes ...string) error {
+	own := claims.Role != Driver && (owner == "" || owner == fmt.Sprint(claims.UserID))
+
+	for _, scope := range scopes {
+		switch {
+		case own && HasScope(claims.Role, scope):
+		case !own && HasScope(claims.Role, scope+AnyUser):
+		case !own && passenger && HasScope(claims.Role, scope+Passenger):
+		default:
+			return fmt.Errorf("role %v: scope %v: %w", claims.Role, scope, ErrForbidden)
+		}
+	}
+	return nil
+}
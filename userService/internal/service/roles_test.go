@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestAuthorize(t *testing.T) {
+	test := []struct {
+		name      string
+		claims    *service.AccessClaims
+		owner     string
+		passenger bool
+		scopes    []string
+		err       error
+	}{
+		{
+			name:   "user reads own profile",
+			claims: &service.AccessClaims{UserID: 1, Role: service.User},
+			owner:  "1",
+			scopes: []string{service.ScopeProfileRead},
+			err:    nil,
+		},
+		{
+			name:   "user reads other profile",
+			claims: &service.AccessClaims{UserID: 1, Role: service.User},
+			owner:  "2",
+			scopes: []string{service.ScopeProfileRead},
+			err:    service.ErrForbidden,
+		},
+		{
+			name:   "user creates order",
+			claims: &service.AccessClaims{UserID: 1, Role: service.User},
+			owner:  "",
+			scopes: []string{service.ScopeOrdersWrite},
+			err:    nil,
+		},
+		{
+			name:   "admin deletes other profile",
+			claims: &service.AccessClaims{UserID: 1, Role: service.Admin},
+			owner:  "2",
+			scopes: []string{service.ScopeProfileDelete},
+			err:    nil,
+		},
+		{
+			name:   "admin reads other orders",
+			claims: &service.AccessClaims{UserID: 1, Role: service.Admin},
+			owner:  "2",
+			scopes: []string{service.ScopeOrdersRead},
+			err:    service.ErrForbidden,
+		},
+		{
+			name:      "driver reads passenger profile",
+			claims:    &service.AccessClaims{UserID: 2, Role: service.Driver},
+			owner:     "2",
+			passenger: true,
+			scopes:    []string{service.ScopeProfileRead},
+			err:       nil,
+		},
+		{
+			name:      "driver reads other profile",
+			claims:    &service.AccessClaims{UserID: 2, Role: service.Driver},
+			owner:     "3",
+			passenger: false,
+			scopes:    []string{service.ScopeProfileRead},
+			err:       service.ErrForbidden,
+		},
+		{
+			name:      "driver updates passenger profile",
+			claims:    &service.AccessClaims{UserID: 2, Role: service.Driver},
+			owner:     "2",
+			passenger: true,
+			scopes:    []string{service.ScopeProfileWrite},
+			err:       service.ErrForbidden,
+		},
+		{
+			name:      "user passenger flag ignored",
+			claims:    &service.AccessClaims{UserID: 1, Role: service.User},
+			owner:     "2",
+			passenger: true,
+			scopes:    []string{service.ScopeProfileRead},
+			err:       service.ErrForbidden,
+		},
+		{
+			name:   "driver creates order",
+			claims: &service.AccessClaims{UserID: 2, Role: service.Driver},
+			owner:  "",
+			scopes: []string{service.ScopeOrdersWrite},
+			err:    service.ErrForbidden,
+		},
+		{
+			name:   "no scopes",
+			claims: &service.AccessClaims{UserID: 2, Role: service.Driver},
+			owner:  "1",
+			scopes: nil,
+			err:    nil,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.Authorize(tt.claims, tt.owner, tt.passenger, tt.scopes...)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/RipperAcskt/innotaxi/config"
 	"github.com/RipperAcskt/innotaxi/internal/model"
@@ -10,13 +11,24 @@ import (
 //go:generate mockgen -destination=mocks/mock_auth.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service AuthRepo
 //go:generate mockgen -destination=mocks/mock_token.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service TokenRepo
 //go:generate mockgen -destination=mocks/mock_user.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service UserRepo
+//go:generate mockgen -destination=mocks/mock_order.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service OrderRepo
+//go:generate mockgen -destination=mocks/mock_driver.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service DriverRepo
+//go:generate mockgen -destination=mocks/mock_sms.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service SmsSender
+//go:generate mockgen -destination=mocks/mock_otp.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service OTPRepo
+//go:generate mockgen -destination=mocks/mock_admin.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service AdminRepo
+//go:generate mockgen -destination=mocks/mock_log.go -package=mocks github.com/RipperAcskt/innotaxi/internal/service LogRepo
 type Service struct {
 	*AuthService
 	*UserService
+	*OrderService
+	*AdminService
+	*LogService
 }
 type Repo interface {
 	AuthRepo
 	UserRepo
+	OrderRepo
+	AdminRepo
 }
 type UserRepo interface {
 	GetUserById(ctx context.Context, id string) (*model.User, error)
@@ -25,25 +37,65 @@ type UserRepo interface {
 }
 type UserService struct {
 	UserRepo
+	otp    otp
+	mailer Mailer
+	sms    SmsSender
 }
 
-func New(postgres Repo, redis TokenRepo, salt string, cfg *config.Config) *Service {
+func New(postgres Repo, redis TokenRepo, mongo LogRepo, drivers DriverRepo, sms SmsSender, mailer Mailer, keys *KeySet, salt string, cfg *config.Config) *Service {
 	return &Service{
-		AuthService: NewAuthSevice(postgres, redis, salt, cfg),
-		UserService: NewUserService(postgres),
+		AuthService:  NewAuthSevice(postgres, redis, sms, mailer, keys, salt, cfg),
+		UserService:  NewUserService(postgres, redis, mailer, sms, cfg),
+		OrderService: NewOrderService(postgres, drivers, cfg),
+		AdminService: NewAdminService(postgres),
+		LogService:   NewLogService(mongo),
 	}
 }
 
-func NewUserService(postgres UserRepo) *UserService {
-	return &UserService{postgres}
+func NewUserService(postgres UserRepo, redis OTPRepo, mailer Mailer, sms SmsSender, cfg *config.Config) *UserService {
+	return &UserService{postgres, otp{redis, cfg}, mailer, sms}
 }
 
 func (user *UserService) GetProfile(ctx context.Context, id string) (*model.User, error) {
 	return user.GetUserById(ctx, id)
 }
 
-func (user *UserService) UpdateProfile(ctx context.Context, id string, userUpdate *model.User) error {
-	return user.UpdateUserById(ctx, id, userUpdate)
+// UpdateProfile updates the name at once. New email and phone number are
+// stored only after the code sent to them is confirmed, the fields waiting
+// for confirmation are returned.
+func (user *UserService) UpdateProfile(ctx context.Context, id string, userUpdate *model.User) ([]string, error) {
+	userUpdate.Name = NormalizeName(userUpdate.Name)
+	userUpdate.PhoneNumber = NormalizePhone(userUpdate.PhoneNumber)
+	userUpdate.Email = NormalizeEmail(userUpdate.Email)
+
+	current, err := user.GetUserById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id failed: %w", err)
+	}
+
+	pending := make([]string, 0)
+	if userUpdate.Email != "" && userUpdate.Email != current.Email {
+		err = user.requestChange(ctx, id, FieldEmail, userUpdate.Email)
+		if err != nil {
+			return nil, fmt.Errorf("request email change failed: %w", err)
+		}
+		pending = append(pending, FieldEmail)
+	}
+	if userUpdate.PhoneNumber != "" && userUpdate.PhoneNumber != current.PhoneNumber {
+		err = user.requestChange(ctx, id, FieldPhone, userUpdate.PhoneNumber)
+		if err != nil {
+			return nil, fmt.Errorf("request phone number change failed: %w", err)
+		}
+		pending = append(pending, FieldPhone)
+	}
+
+	if userUpdate.Name != "" {
+		err = user.UpdateUserById(ctx, id, &model.User{Name: userUpdate.Name})
+		if err != nil {
+			return nil, fmt.Errorf("update user by id failed: %w", err)
+		}
+	}
+	return pending, nil
 }
 
 func (user *UserService) DeleteUser(ctx context.Context, id string) error {
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RipperAcskt/innotaxi/internal/model"
+)
+
+var ErrSessionNotFound = NewError(CodeNotFound, "session not found")
+
+// SessionRepo stores the sessions of users. Deleting a session deletes the
+// family of its refresh tokens as well.
+type SessionRepo interface {
+	AddSession(ctx context.Context, session *model.Session, expired time.Duration) error
+	CheckSession(ctx context.Context, id string) (bool, error)
+	GetSessionsByUserId(ctx context.Context, userID string) ([]*model.Session, error)
+	DeleteSessionById(ctx context.Context, userID, id string) error
+	DeleteSessionsByUserId(ctx context.Context, userID string) error
+}
+
+// GetSessions returns the user's sessions, the one of current session id is
+// marked as current.
+func (s *AuthService) GetSessions(ctx context.Context, userID, current string) ([]*model.Session, error) {
+	sessions, err := s.GetSessionsByUserId(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get sessions by user id failed: %w", err)
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID == current
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs the user out on one device. Access tokens of the session
+// are rejected at once and its refresh token can't be used anymore.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, id string) error {
+	return s.DeleteSessionById(ctx, userID, id)
+}
+
+// RevokeSessions signs the user out on every device.
+func (s *AuthService) RevokeSessions(ctx context.Context, userID string) error {
+	return s.DeleteSessionsByUserId(ctx, userID)
+}
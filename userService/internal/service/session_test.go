@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/notify"
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/RipperAcskt/innotaxi/internal/service/mocks"
+	"github.com/go-playground/assert/v2"
+	"github.com/golang/mock/gomock"
+)
+
+var errTest = fmt.Errorf("test error")
+
+func TestGetSessions(t *testing.T) {
+	type mockBehavior func(r *mocks.MockTokenRepo, userID string)
+	type fileds struct {
+		authRepo  *mocks.MockAuthRepo
+		tokenRepo *mocks.MockTokenRepo
+	}
+	test := []struct {
+		name         string
+		userID       string
+		current      string
+		mockBehavior mockBehavior
+		sessions     []*model.Session
+		err          error
+	}{
+		{
+			name:    "current session marked",
+			userID:  "1",
+			current: "b",
+			mockBehavior: func(r *mocks.MockTokenRepo, userID string) {
+				r.EXPECT().GetSessionsByUserId(context.Background(), userID).Return([]*model.Session{
+					{ID: "a", UserID: 1, Device: "phone"},
+					{ID: "b", UserID: 1, Device: "laptop"},
+				}, nil)
+			},
+			sessions: []*model.Session{
+				{ID: "a", UserID: 1, Device: "phone", Current: false},
+				{ID: "b", UserID: 1, Device: "laptop", Current: true},
+			},
+			err: nil,
+		},
+		{
+			name:    "get sessions failed",
+			userID:  "1",
+			current: "b",
+			mockBehavior: func(r *mocks.MockTokenRepo, userID string) {
+				r.EXPECT().GetSessionsByUserId(context.Background(), userID).Return(nil, errTest)
+			},
+			sessions: nil,
+			err:      errTest,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fileds{
+				authRepo:  mocks.NewMockAuthRepo(ctrl),
+				tokenRepo: mocks.NewMockTokenRepo(ctrl),
+			}
+			authService := service.NewAuthSevice(f.authRepo, f.tokenRepo, mocks.NewMockSmsSender(ctrl), notify.NewMemoryMailer(), hs256Keys, "124jkhsdaf3425", &config.Config{})
+
+			tt.mockBehavior(f.tokenRepo, tt.userID)
+
+			service := service.Service{
+				AuthService: authService,
+			}
+
+			sessions, err := service.GetSessions(context.Background(), tt.userID, tt.current)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			assert.Equal(t, sessions, tt.sessions)
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	type mockBehavior func(r *mocks.MockTokenRepo, userID, id string)
+	type fileds struct {
+		authRepo  *mocks.MockAuthRepo
+		tokenRepo *mocks.MockTokenRepo
+	}
+	test := []struct {
+		name         string
+		userID       string
+		id           string
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:   "revoke session",
+			userID: "1",
+			id:     "a",
+			mockBehavior: func(r *mocks.MockTokenRepo, userID, id string) {
+				r.EXPECT().DeleteSessionById(context.Background(), userID, id).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:   "session of other user",
+			userID: "1",
+			id:     "c",
+			mockBehavior: func(r *mocks.MockTokenRepo, userID, id string) {
+				r.EXPECT().DeleteSessionById(context.Background(), userID, id).Return(service.ErrSessionNotFound)
+			},
+			err: service.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fileds{
+				authRepo:  mocks.NewMockAuthRepo(ctrl),
+				tokenRepo: mocks.NewMockTokenRepo(ctrl),
+			}
+			authService := service.NewAuthSevice(f.authRepo, f.tokenRepo, mocks.NewMockSmsSender(ctrl), notify.NewMemoryMailer(), hs256Keys, "124jkhsdaf3425", &config.Config{})
+
+			tt.mockBehavior(f.tokenRepo, tt.userID, tt.id)
+
+			service := service.Service{
+				AuthService: authService,
+			}
+
+			err := service.RevokeSession(context.Background(), tt.userID, tt.id)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+		})
+	}
+}
+
+func TestVerifyAccess(t *testing.T) {
+	type mockBehavior func(r *mocks.MockTokenRepo, sid string)
+	type fileds struct {
+		authRepo  *mocks.MockAuthRepo
+		tokenRepo *mocks.MockTokenRepo
+	}
+
+	token, _ := service.NewToken(service.TokenParams{
+		ID:                uint64(1),
+		Type:              service.User,
+		Keys:              hs256Keys,
+		ACCESS_TOKEN_EXP:  1,
+		REFRESH_TOKEN_EXP: 1,
+	})
+
+	driver, _ := service.NewToken(service.TokenParams{
+		ID:                "7",
+		Type:              service.Driver,
+		Keys:              hs256Keys,
+		ACCESS_TOKEN_EXP:  1,
+		REFRESH_TOKEN_EXP: 1,
+	})
+
+	test := []struct {
+		name         string
+		token        string
+		mockBehavior mockBehavior
+		claims       *service.AccessClaims
+		err          error
+	}{
+		{
+			name:  "session active",
+			token: token.Access,
+			mockBehavior: func(r *mocks.MockTokenRepo, sid string) {
+				r.EXPECT().CheckSession(context.Background(), sid).Return(true, nil)
+			},
+			claims: &service.AccessClaims{UserID: 1, SessionID: token.Family, Role: service.User},
+			err:    nil,
+		},
+		{
+			name:  "session revoked",
+			token: token.Access,
+			mockBehavior: func(r *mocks.MockTokenRepo, sid string) {
+				r.EXPECT().CheckSession(context.Background(), sid).Return(false, nil)
+			},
+			claims: nil,
+			err:    service.ErrSessionRevoked,
+		},
+		{
+			name:         "driver token without session",
+			token:        driver.Access,
+			mockBehavior: func(r *mocks.MockTokenRepo, sid string) {},
+			claims:       &service.AccessClaims{UserID: 7, SessionID: driver.Family, Role: service.Driver},
+			err:          nil,
+		},
+		{
+			name:         "refresh token as access token",
+			token:        token.RT,
+			mockBehavior: func(r *mocks.MockTokenRepo, sid string) {},
+			claims:       nil,
+			err:          service.ErrWrongTokenUse,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fileds{
+				authRepo:  mocks.NewMockAuthRepo(ctrl),
+				tokenRepo: mocks.NewMockTokenRepo(ctrl),
+			}
+			authService := service.NewAuthSevice(f.authRepo, f.tokenRepo, mocks.NewMockSmsSender(ctrl), notify.NewMemoryMailer(), hs256Keys, "124jkhsdaf3425", &config.Config{})
+
+			tt.mockBehavior(f.tokenRepo, token.Family)
+
+			service := service.Service{
+				AuthService: authService,
+			}
+
+			claims, err := service.VerifyAccess(context.Background(), tt.token)
+			assert.Equal(t, errors.Is(err, tt.err), true)
+			assert.Equal(t, claims, tt.claims)
+		})
+	}
+}
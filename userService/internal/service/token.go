@@ -1,21 +1,37 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
-	"github.com/RipperAcskt/innotaxi/config"
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 )
 
+// Types of tokens, the type is the role of the token's subject.
 const (
 	User   = "user"
 	Driver = "driver"
+	Admin  = "admin"
+)
+
+const (
+	TokenUseAccess  = "access"
+	TokenUseRefresh = "refresh"
+
+	Issuer   = "innotaxi-user"
+	Audience = "innotaxi"
 )
 
 var (
-	ErrTokenExpired = fmt.Errorf("token expired")
-	ErrUnknownType  = fmt.Errorf("unknown type")
+	ErrTokenExpired  = NewError(CodeUnauthenticated, "token expired")
+	ErrUnknownType   = NewError(CodeUnauthenticated, "unknown type")
+	ErrBadRT         = NewError(CodeUnauthenticated, "bad refresh token")
+	ErrWrongTokenUse = NewError(CodeUnauthenticated, "wrong token use")
+	ErrInvalidToken  = NewError(CodeUnauthenticated, "invalid token")
+	ErrTokenRequired = NewError(CodeUnauthenticated, "access token required")
 )
 
 type Token struct {
@@ -23,48 +39,86 @@ type Token struct {
 	RT               string `json:"refresh_token"`
 	AccessExpiration time.Time
 	RTExpiration     time.Time
+	// RTID is the jti of the refresh token and Family is the id shared by
+	// all the refresh tokens rotated from the same sign in.
+	RTID   string `json:"-"`
+	Family string `json:"-"`
 }
 
 type TokenParams struct {
 	ID                any
 	Type              string
-	HS256_SECRET      string
+	Keys              *KeySet
 	ACCESS_TOKEN_EXP  int
 	REFRESH_TOKEN_EXP int
+	// Family of the refresh token, a new one is started if empty.
+	Family string
+}
+
+// AccessClaims are the claims of a verified access token.
+type AccessClaims struct {
+	UserID    uint64
+	SessionID string
+	Role      string
+}
+
+// RTClaims are the claims of a verified refresh token.
+type RTClaims struct {
+	UserID uint64
+	ID     string
+	Family string
+	Role   string
+}
+
+func knownType(t interface{}) bool {
+	return t == User || t == Driver || t == Admin
 }
 
 func NewToken(params TokenParams) (*Token, error) {
-	if params.Type != User && params.Type != Driver {
+	if !knownType(params.Type) {
 		return nil, ErrUnknownType
 	}
 
+	family := params.Family
+	if family == "" {
+		family = uuid.NewString()
+	}
+
 	accessExp := time.Now().Add(time.Duration(params.ACCESS_TOKEN_EXP) * time.Minute)
 
-	access, err := newJwt(accessExp, params)
+	access, err := newJwt(accessExp, TokenUseAccess, uuid.NewString(), params, jwt.MapClaims{"sid": family})
 	if err != nil {
 		return nil, fmt.Errorf("new jwt failed: %w", err)
 	}
 
 	rtExp := time.Now().Add(time.Duration(params.REFRESH_TOKEN_EXP) * 24 * time.Hour)
 
-	rt, err := newJwt(rtExp, params)
+	rtID := uuid.NewString()
+
+	rt, err := newJwt(rtExp, TokenUseRefresh, rtID, params, jwt.MapClaims{"family": family})
 	if err != nil {
 		return nil, fmt.Errorf("new rt failed: %w", err)
 	}
 
-	return &Token{access, rt, accessExp, rtExp}, nil
+	return &Token{access, rt, accessExp, rtExp, rtID, family}, nil
 }
 
-func newJwt(jwtExp time.Time, p TokenParams) (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+func newJwt(jwtExp time.Time, use, id string, p TokenParams, extra jwt.MapClaims) (string, error) {
+	claims := jwt.MapClaims{}
 
 	claims["user_id"] = p.ID
 	claims["type"] = p.Type
+	claims["token_use"] = use
+	claims["jti"] = id
+	claims["iss"] = Issuer
+	claims["aud"] = Audience
+	claims["iat"] = time.Now().UTC().Unix()
 	claims["exp"] = jwtExp.UTC().Unix()
+	for k, v := range extra {
+		claims[k] = v
+	}
 
-	secret := []byte(p.HS256_SECRET)
-	tokenString, err := token.SignedString(secret)
+	tokenString, err := p.Keys.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("signed string failed: %w", err)
 	}
@@ -72,28 +126,107 @@ func newJwt(jwtExp time.Time, p TokenParams) (string, error) {
 	return tokenString, nil
 }
 
-func Verify(token string, cfg *config.Config) (uint64, error) {
-	tokenJwt, err := jwt.Parse(
-		token,
-		func(token *jwt.Token) (interface{}, error) {
-			return []byte(cfg.HS256_SECRET), nil
-		},
-	)
+// Verify verifies the token and checks that it was issued for use, so a
+// refresh token can't be used as an access token and vice versa.
+func Verify(token, use string, keys *KeySet) (uint64, error) {
+	claims, err := parse(token, use, keys)
+	if err != nil {
+		return 0, err
+	}
+	return subject(claims)
+}
 
+// VerifyAccess verifies the access token and returns its session id, the
+// family of the refresh token it was issued with.
+func VerifyAccess(token string, keys *KeySet) (*AccessClaims, error) {
+	claims, err := parse(token, TokenUseAccess, keys)
 	if err != nil {
-		return 0, fmt.Errorf("token parse failed: %w", err)
+		return nil, err
+	}
+
+	id, err := subject(claims)
+	if err != nil {
+		return nil, err
+	}
+	sid, ok := claims["sid"].(string)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return &AccessClaims{id, sid, claims["type"].(string)}, nil
+}
+
+// VerifyRefresh verifies the refresh token and returns its jti and family.
+func VerifyRefresh(token string, keys *KeySet) (*RTClaims, error) {
+	claims, err := parse(token, TokenUseRefresh, keys)
+	if err != nil {
+		if errors.Is(err, ErrWrongTokenUse) {
+			return nil, fmt.Errorf("%v: %w", err, ErrBadRT)
+		}
+		return nil, err
+	}
+
+	id, ok := claims["jti"].(string)
+	if !ok {
+		return nil, ErrBadRT
+	}
+	family, ok := claims["family"].(string)
+	if !ok {
+		return nil, ErrBadRT
+	}
+	userID, err := subject(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrBadRT)
+	}
+
+	return &RTClaims{userID, id, family, claims["type"].(string)}, nil
+}
+
+// subject returns the id of the token's subject. Driver tokens are issued
+// over gRPC with the id passed as a string.
+func subject(claims jwt.MapClaims) (uint64, error) {
+	switch id := claims["user_id"].(type) {
+	case string:
+		userID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse uint failed: %v: %w", err, ErrInvalidToken)
+		}
+		return userID, nil
+	case float64:
+		return uint64(id), nil
+	}
+	return 0, ErrInvalidToken
+}
+
+func parse(token, use string, keys *KeySet) (jwt.MapClaims, error) {
+	tokenJwt, err := jwt.Parse(token, keys.keyfunc)
+
+	if err != nil {
+		var ve *jwt.ValidationError
+		if errors.As(err, &ve) && ve.Errors&jwt.ValidationErrorExpired != 0 {
+			return nil, ErrTokenExpired
+		}
+		if errors.As(err, &ve) && errors.Is(ve.Inner, ErrUnknownKey) {
+			return nil, fmt.Errorf("%v: %w", ve.Inner, ErrInvalidToken)
+		}
+		return nil, fmt.Errorf("token parse failed: %v: %w", err, ErrInvalidToken)
 	}
 
 	claims, ok := tokenJwt.Claims.(jwt.MapClaims)
 	if !ok {
-		return 0, fmt.Errorf("jwt map claims failed")
+		return nil, fmt.Errorf("jwt map claims failed")
 	}
 
 	if !claims.VerifyExpiresAt(time.Now().UTC().Unix(), true) {
-		return 0, ErrTokenExpired
+		return nil, ErrTokenExpired
+	}
+	if !claims.VerifyIssuer(Issuer, true) || !claims.VerifyAudience(Audience, true) {
+		return nil, ErrInvalidToken
 	}
-	if string(claims["type"].(string)) != User {
-		return 0, ErrUnknownType
+	if claims["token_use"] != use {
+		return nil, ErrWrongTokenUse
+	}
+	if !knownType(claims["type"]) {
+		return nil, ErrUnknownType
 	}
-	return uint64(claims["user_id"].(float64)), nil
+	return claims, nil
 }
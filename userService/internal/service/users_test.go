@@ -2,9 +2,13 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/RipperAcskt/innotaxi/config"
 	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/notify"
 	"github.com/RipperAcskt/innotaxi/internal/service"
 	"github.com/RipperAcskt/innotaxi/internal/service/mocks"
 	"github.com/go-playground/assert/v2"
@@ -38,7 +42,7 @@ func TestGetProfile(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mocks.NewMockUserRepo(ctrl)
-			userService := service.NewUserService(userRepo)
+			userService := service.NewUserService(userRepo, mocks.NewMockOTPRepo(ctrl), notify.NewMemoryMailer(), mocks.NewMockSmsSender(ctrl), &config.Config{})
 
 			tt.mockBehavior(userRepo)
 
@@ -53,24 +57,52 @@ func TestGetProfile(t *testing.T) {
 }
 
 func TestUpdateProfile(t *testing.T) {
-	type mockBehavior func(s *mocks.MockUserRepo, user model.User)
+	type mockBehavior func(s *mocks.MockUserRepo, o *mocks.MockOTPRepo, sms *mocks.MockSmsSender)
+
+	current := &model.User{
+		Name:        "Ivan",
+		PhoneNumber: "+77777777",
+		Email:       "ripper@mail.ru",
+	}
 
 	test := []struct {
 		name         string
 		user         model.User
 		mockBehavior mockBehavior
+		pending      []string
+		mails        int
 		err          error
 	}{
 		{
-			name: "update user",
+			name: "update name",
 			user: model.User{
-				PhoneNumber: "+77777778",
+				Name:        "Oleg",
+				PhoneNumber: "+77777777",
 				Email:       "ripper@mail.ru",
 			},
-			mockBehavior: func(s *mocks.MockUserRepo, user model.User) {
-				s.EXPECT().UpdateUserById(context.Background(), "", &user).Return(nil)
+			mockBehavior: func(s *mocks.MockUserRepo, o *mocks.MockOTPRepo, sms *mocks.MockSmsSender) {
+				s.EXPECT().GetUserById(context.Background(), "1").Return(current, nil)
+				s.EXPECT().UpdateUserById(context.Background(), "1", &model.User{Name: "Oleg"}).Return(nil)
 			},
-			err: nil,
+			pending: []string{},
+			mails:   0,
+			err:     nil,
+		},
+		{
+			name: "update email and phone number",
+			user: model.User{
+				PhoneNumber: "+77777778",
+				Email:       "ivan@mail.ru",
+			},
+			mockBehavior: func(s *mocks.MockUserRepo, o *mocks.MockOTPRepo, sms *mocks.MockSmsSender) {
+				s.EXPECT().GetUserById(context.Background(), "1").Return(current, nil)
+				o.EXPECT().AddOTP(context.Background(), "change:email:1", gomock.Any(), "ivan@mail.ru", 5*time.Minute).Return(nil)
+				o.EXPECT().AddOTP(context.Background(), "change:phone_number:1", gomock.Any(), "+77777778", 5*time.Minute).Return(nil)
+				sms.EXPECT().Send(context.Background(), "+77777778", gomock.Any()).Return(nil)
+			},
+			pending: []string{service.FieldEmail, service.FieldPhone},
+			mails:   1,
+			err:     nil,
 		},
 	}
 
@@ -80,16 +112,88 @@ func TestUpdateProfile(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mocks.NewMockUserRepo(ctrl)
-			userService := service.NewUserService(userRepo)
+			otpRepo := mocks.NewMockOTPRepo(ctrl)
+			sms := mocks.NewMockSmsSender(ctrl)
+			mailer := notify.NewMemoryMailer()
+			userService := service.NewUserService(userRepo, otpRepo, mailer, sms, &config.Config{})
 
-			tt.mockBehavior(userRepo, tt.user)
+			tt.mockBehavior(userRepo, otpRepo, sms)
 
 			service := service.Service{
 				UserService: userService,
 			}
 
-			err := service.UpdateProfile(context.Background(), "", &tt.user)
+			pending, err := service.UpdateProfile(context.Background(), "1", &tt.user)
 			assert.Equal(t, err, tt.err)
+			assert.Equal(t, pending, tt.pending)
+
+			mails := mailer.Mails()
+			assert.Equal(t, len(mails), tt.mails)
+			if tt.mails > 0 {
+				assert.Equal(t, mails[0].To, tt.user.Email)
+			}
+		})
+	}
+}
+
+func TestConfirmChange(t *testing.T) {
+	type mockBehavior func(s *mocks.MockUserRepo, o *mocks.MockOTPRepo)
+
+	test := []struct {
+		name         string
+		field        string
+		code         string
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:  "confirm email",
+			field: service.FieldEmail,
+			code:  "123456",
+			mockBehavior: func(s *mocks.MockUserRepo, o *mocks.MockOTPRepo) {
+				o.EXPECT().CheckOTP(context.Background(), "change:email:1", "123456", 5).Return("ivan@mail.ru", true, nil)
+				s.EXPECT().UpdateUserById(context.Background(), "1", &model.User{Email: "ivan@mail.ru"}).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:  "confirm phone number",
+			field: service.FieldPhone,
+			code:  "123456",
+			mockBehavior: func(s *mocks.MockUserRepo, o *mocks.MockOTPRepo) {
+				o.EXPECT().CheckOTP(context.Background(), "change:phone_number:1", "123456", 5).Return("+77777778", true, nil)
+				s.EXPECT().UpdateUserById(context.Background(), "1", &model.User{PhoneNumber: "+77777778"}).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:  "wrong code",
+			field: service.FieldEmail,
+			code:  "654321",
+			mockBehavior: func(s *mocks.MockUserRepo, o *mocks.MockOTPRepo) {
+				o.EXPECT().CheckOTP(context.Background(), "change:email:1", "654321", 5).Return("", false, nil)
+			},
+			err: service.ErrWrongOTP,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mocks.NewMockUserRepo(ctrl)
+			otpRepo := mocks.NewMockOTPRepo(ctrl)
+			userService := service.NewUserService(userRepo, otpRepo, notify.NewMemoryMailer(), mocks.NewMockSmsSender(ctrl), &config.Config{})
+
+			tt.mockBehavior(userRepo, otpRepo)
+
+			service := service.Service{
+				UserService: userService,
+			}
+
+			err := service.ConfirmChange(context.Background(), "1", tt.field, tt.code)
+			assert.Equal(t, errors.Is(err, tt.err), true)
 		})
 	}
 }
@@ -117,7 +221,7 @@ func TestDeleteProfile(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mocks.NewMockUserRepo(ctrl)
-			userService := service.NewUserService(userRepo)
+			userService := service.NewUserService(userRepo, mocks.NewMockOTPRepo(ctrl), notify.NewMemoryMailer(), mocks.NewMockSmsSender(ctrl), &config.Config{})
 
 			tt.mockBehavior(userRepo)
 
@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/RipperAcskt/innotaxi/internal/service"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	test := []struct {
+		name  string
+		phone string
+		want  string
+	}{
+		{
+			name:  "e164",
+			phone: "+79001234567",
+			want:  "+79001234567",
+		},
+		{
+			name:  "separators",
+			phone: " +7 (900) 123-45.67 ",
+			want:  "+79001234567",
+		},
+		{
+			name:  "international prefix",
+			phone: "0079001234567",
+			want:  "+79001234567",
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, service.NormalizePhone(tt.phone), tt.want)
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	test := []struct {
+		name  string
+		valid func(string) bool
+		value string
+		want  bool
+	}{
+		{
+			name:  "phone",
+			valid: service.ValidPhone,
+			value: "+79001234567",
+			want:  true,
+		},
+		{
+			name:  "phone without plus",
+			valid: service.ValidPhone,
+			value: "79001234567",
+			want:  false,
+		},
+		{
+			name:  "too long phone",
+			valid: service.ValidPhone,
+			value: "+7900123456789012",
+			want:  false,
+		},
+		{
+			name:  "email",
+			valid: service.ValidEmail,
+			value: "ripper@mail.ru",
+			want:  true,
+		},
+		{
+			name:  "email with name",
+			valid: service.ValidEmail,
+			value: "Ripper <ripper@mail.ru>",
+			want:  false,
+		},
+		{
+			name:  "email without domain",
+			valid: service.ValidEmail,
+			value: "ripper@",
+			want:  false,
+		},
+		{
+			name:  "too long email",
+			valid: service.ValidEmail,
+			value: "ripper.ripper.ripper@mail.ripper.ru",
+			want:  false,
+		},
+		{
+			name:  "name",
+			valid: service.ValidName,
+			value: "Anna-Maria O'Neil",
+			want:  true,
+		},
+		{
+			name:  "cyrillic name",
+			valid: service.ValidName,
+			value: "Иван",
+			want:  true,
+		},
+		{
+			name:  "name with digits",
+			valid: service.ValidName,
+			value: "Ivan2",
+			want:  false,
+		},
+		{
+			name:  "name starting with hyphen",
+			valid: service.ValidName,
+			value: "-Ivan",
+			want:  false,
+		},
+		{
+			name:  "password",
+			valid: service.ValidPassword,
+			value: "Qwerty123",
+			want:  true,
+		},
+		{
+			name:  "short password",
+			valid: service.ValidPassword,
+			value: "Qwe123",
+			want:  false,
+		},
+		{
+			name:  "password without uppercase letter",
+			valid: service.ValidPassword,
+			value: "qwerty123",
+			want:  false,
+		},
+	}
+
+	for _, tt := range test {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid(tt.value), tt.want)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+
+	"github.com/RipperAcskt/innotaxi/config"
+	handler "github.com/RipperAcskt/innotaxi/internal/handler/restapi"
+	"github.com/RipperAcskt/innotaxi/internal/model"
+	"github.com/RipperAcskt/innotaxi/internal/repo/postgres"
+	"github.com/RipperAcskt/innotaxi/internal/tracing"
+)
+
+const (
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent = "00-" + traceID + "-00f067aa0ba902b7-01"
+)
+
+type span struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		TraceID string
+		SpanID  string
+	}
+	Attributes []struct {
+		Key   string
+		Value struct {
+			Value interface{}
+		}
+	}
+}
+
+func (s *span) attribute(key string) interface{} {
+	for _, attr := range s.Attributes {
+		if attr.Key == key {
+			return attr.Value.Value
+		}
+	}
+	return nil
+}
+
+func TestTrace(t *testing.T) {
+	out := &bytes.Buffer{}
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+	if err != nil {
+		log.Fatalf("stdout trace new failed: %v", err)
+	}
+	provider := tracing.NewProvider(exporter)
+	otel.SetTracerProvider(provider)
+
+	// Without an exporter New only sets the propagator.
+	shutdown, err := tracing.New(context.Background(), &config.Config{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, shutdown(context.Background()), nil)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("sqlmock new failed: %v", err)
+	}
+	mock.ExpectQuery("SELECT password FROM users").WithArgs(1, model.StatusCreated).WillReturnRows(sqlmock.NewRows([]string{"password"}).AddRow("12345"))
+	postgres := &postgres.Postgres{DB: db}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use((&handler.Handler{}).Trace())
+	router.GET("/users/:id/password", func(c *gin.Context) {
+		_, err := postgres.GetPasswordById(c.Request.Context(), 1)
+		assert.Equal(t, err, nil)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1/password", nil)
+	req.Header.Set("traceparent", traceparent)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	err = provider.Shutdown(context.Background())
+	assert.Equal(t, err, nil)
+
+	spans := make(map[string]*span)
+	dec := json.NewDecoder(out)
+	for dec.More() {
+		s := &span{}
+		err := dec.Decode(s)
+		assert.Equal(t, err, nil)
+		spans[s.Name] = s
+	}
+	assert.Equal(t, len(spans), 2)
+
+	server, ok := spans["GET /users/:id/password"]
+	assert.Equal(t, ok, true)
+	assert.Equal(t, server.SpanContext.TraceID, traceID)
+	assert.Equal(t, server.Parent.SpanID, "00f067aa0ba902b7")
+	assert.Equal(t, server.attribute("http.route"), "/users/:id/password")
+	assert.Equal(t, server.attribute("http.status_code"), float64(http.StatusOK))
+
+	query, ok := spans["postgres SELECT"]
+	assert.Equal(t, ok, true)
+	assert.Equal(t, query.SpanContext.TraceID, traceID)
+	assert.Equal(t, query.Parent.SpanID, server.SpanContext.SpanID)
+	assert.Equal(t, query.attribute("db.system"), "postgresql")
+	assert.Equal(t, query.attribute("db.statement"), "SELECT password FROM users WHERE id = $1 AND status = $2")
+}
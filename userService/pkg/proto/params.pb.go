@@ -138,6 +138,411 @@ func (x *Response) GetRefreshToken() string {
 	return ""
 }
 
+type FindDriverRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID   uint64 `protobuf:"varint,1,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	TaxiType string `protobuf:"bytes,2,opt,name=TaxiType,proto3" json:"TaxiType,omitempty"`
+	From     string `protobuf:"bytes,3,opt,name=From,proto3" json:"From,omitempty"`
+	To       string `protobuf:"bytes,4,opt,name=To,proto3" json:"To,omitempty"`
+}
+
+func (x *FindDriverRequest) Reset() {
+	*x = FindDriverRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_params_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDriverRequest) ProtoMessage() {}
+
+func (x *FindDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_params_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDriverRequest.ProtoReflect.Descriptor instead.
+func (*FindDriverRequest) Descriptor() ([]byte, []int) {
+	return file_params_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FindDriverRequest) GetUserID() uint64 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+func (x *FindDriverRequest) GetTaxiType() string {
+	if x != nil {
+		return x.TaxiType
+	}
+	return ""
+}
+
+func (x *FindDriverRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FindDriverRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type FindDriverResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DriverID string `protobuf:"bytes,1,opt,name=DriverID,proto3" json:"DriverID,omitempty"`
+}
+
+func (x *FindDriverResponse) Reset() {
+	*x = FindDriverResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_params_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindDriverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDriverResponse) ProtoMessage() {}
+
+func (x *FindDriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_params_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDriverResponse.ProtoReflect.Descriptor instead.
+func (*FindDriverResponse) Descriptor() ([]byte, []int) {
+	return file_params_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FindDriverResponse) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_params_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_params_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_params_proto_rawDescGZIP(), []int{4}
+}
+
+type FreeDriver struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DriverID string `protobuf:"bytes,1,opt,name=DriverID,proto3" json:"DriverID,omitempty"`
+	TaxiType string `protobuf:"bytes,2,opt,name=TaxiType,proto3" json:"TaxiType,omitempty"`
+}
+
+func (x *FreeDriver) Reset() {
+	*x = FreeDriver{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_params_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FreeDriver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeDriver) ProtoMessage() {}
+
+func (x *FreeDriver) ProtoReflect() protoreflect.Message {
+	mi := &file_params_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeDriver.ProtoReflect.Descriptor instead.
+func (*FreeDriver) Descriptor() ([]byte, []int) {
+	return file_params_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FreeDriver) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+func (x *FreeDriver) GetTaxiType() string {
+	if x != nil {
+		return x.TaxiType
+	}
+	return ""
+}
+
+type RateOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DriverID string `protobuf:"bytes,1,opt,name=DriverID,proto3" json:"DriverID,omitempty"`
+	UserID   uint64 `protobuf:"varint,2,opt,name=UserID,proto3" json:"UserID,omitempty"`
+	Rating   int32  `protobuf:"varint,3,opt,name=Rating,proto3" json:"Rating,omitempty"`
+}
+
+func (x *RateOrderRequest) Reset() {
+	*x = RateOrderRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_params_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateOrderRequest) ProtoMessage() {}
+
+func (x *RateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_params_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateOrderRequest.ProtoReflect.Descriptor instead.
+func (*RateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_params_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RateOrderRequest) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+func (x *RateOrderRequest) GetUserID() uint64 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+func (x *RateOrderRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+type RateOrderResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RateOrderResponse) Reset() {
+	*x = RateOrderResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_params_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateOrderResponse) ProtoMessage() {}
+
+func (x *RateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_params_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateOrderResponse.ProtoReflect.Descriptor instead.
+func (*RateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_params_proto_rawDescGZIP(), []int{7}
+}
+
+type ReleaseDriverRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DriverID string `protobuf:"bytes,1,opt,name=DriverID,proto3" json:"DriverID,omitempty"`
+	UserID   uint64 `protobuf:"varint,2,opt,name=UserID,proto3" json:"UserID,omitempty"`
+}
+
+func (x *ReleaseDriverRequest) Reset() {
+	*x = ReleaseDriverRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_params_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDriverRequest) ProtoMessage() {}
+
+func (x *ReleaseDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_params_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDriverRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseDriverRequest) Descriptor() ([]byte, []int) {
+	return file_params_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReleaseDriverRequest) GetDriverID() string {
+	if x != nil {
+		return x.DriverID
+	}
+	return ""
+}
+
+func (x *ReleaseDriverRequest) GetUserID() uint64 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+type ReleaseDriverResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReleaseDriverResponse) Reset() {
+	*x = ReleaseDriverResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_params_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseDriverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDriverResponse) ProtoMessage() {}
+
+func (x *ReleaseDriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_params_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDriverResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseDriverResponse) Descriptor() ([]byte, []int) {
+	return file_params_proto_rawDescGZIP(), []int{9}
+}
+
 var File_params_proto protoreflect.FileDescriptor
 
 var file_params_proto_rawDesc = []byte{
@@ -152,11 +557,56 @@ var file_params_proto_rawDesc = []byte{
 	0x09, 0x52, 0x0b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x22,
 	0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x32, 0x2d, 0x0a, 0x0b, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x1e, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4a, 0x57, 0x54, 0x12, 0x07, 0x2e, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x42, 0x12, 0x5a, 0x10, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x6e, 0x22, 0x6b, 0x0a, 0x11, 0x46, 0x69, 0x6e, 0x64, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x1a, 0x0a, 0x08, 0x54, 0x61, 0x78, 0x69, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x54, 0x61, 0x78, 0x69, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x46,
+	0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12,
+	0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x22,
+	0x30, 0x0a, 0x12, 0x46, 0x69, 0x6e, 0x64, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49,
+	0x44, 0x22, 0x0e, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x44, 0x0a, 0x0a, 0x46, 0x72, 0x65, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x54,
+	0x61, 0x78, 0x69, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x54,
+	0x61, 0x78, 0x69, 0x54, 0x79, 0x70, 0x65, 0x22, 0x5e, 0x0a, 0x10, 0x52, 0x61, 0x74, 0x65, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x44,
+	0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44,
+	0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x16, 0x0a, 0x06, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x13, 0x0a, 0x11, 0x52, 0x61, 0x74, 0x65, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4a, 0x0a, 0x14,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x44,
+	0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x32, 0x2d, 0x0a, 0x0b, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x1e, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4a, 0x57, 0x54, 0x12, 0x07, 0x2e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x1a, 0x09, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x32, 0xf9, 0x01, 0x0a, 0x0e, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x69, 0x6e, 0x67, 0x12, 0x3b, 0x0a, 0x0e, 0x46, 0x69, 0x6e, 0x64, 0x46, 0x72, 0x65, 0x65, 0x44,
+	0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x12, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x44, 0x72, 0x69, 0x76,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x46, 0x69, 0x6e, 0x64,
+	0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x32, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x46, 0x72, 0x65, 0x65, 0x44, 0x72, 0x69,
+	0x76, 0x65, 0x72, 0x73, 0x12, 0x0d, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x09, 0x52, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x11, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x72, 0x69, 0x76,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09,
+	0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -171,16 +621,32 @@ func file_params_proto_rawDescGZIP() []byte {
 	return file_params_proto_rawDescData
 }
 
-var file_params_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_params_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_params_proto_goTypes = []interface{}{
-	(*Params)(nil),   // 0: Params
-	(*Response)(nil), // 1: Response
+	(*Params)(nil),                // 0: Params
+	(*Response)(nil),              // 1: Response
+	(*FindDriverRequest)(nil),     // 2: FindDriverRequest
+	(*FindDriverResponse)(nil),    // 3: FindDriverResponse
+	(*WatchRequest)(nil),          // 4: WatchRequest
+	(*FreeDriver)(nil),            // 5: FreeDriver
+	(*RateOrderRequest)(nil),      // 6: RateOrderRequest
+	(*RateOrderResponse)(nil),     // 7: RateOrderResponse
+	(*ReleaseDriverRequest)(nil),  // 8: ReleaseDriverRequest
+	(*ReleaseDriverResponse)(nil), // 9: ReleaseDriverResponse
 }
 var file_params_proto_depIdxs = []int32{
 	0, // 0: AuthService.GetJWT:input_type -> Params
-	1, // 1: AuthService.GetJWT:output_type -> Response
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+	2, // 1: DriverMatching.FindFreeDriver:input_type -> FindDriverRequest
+	4, // 2: DriverMatching.WatchFreeDrivers:input_type -> WatchRequest
+	6, // 3: DriverMatching.RateOrder:input_type -> RateOrderRequest
+	8, // 4: DriverMatching.ReleaseDriver:input_type -> ReleaseDriverRequest
+	1, // 5: AuthService.GetJWT:output_type -> Response
+	3, // 6: DriverMatching.FindFreeDriver:output_type -> FindDriverResponse
+	5, // 7: DriverMatching.WatchFreeDrivers:output_type -> FreeDriver
+	7, // 8: DriverMatching.RateOrder:output_type -> RateOrderResponse
+	9, // 9: DriverMatching.ReleaseDriver:output_type -> ReleaseDriverResponse
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -216,6 +682,102 @@ func file_params_proto_init() {
 				return nil
 			}
 		}
+		file_params_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindDriverRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_params_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindDriverResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_params_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_params_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FreeDriver); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_params_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_params_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateOrderResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_params_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseDriverRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_params_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseDriverResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -223,9 +785,9 @@ func file_params_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_params_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   10,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_params_proto_goTypes,
 		DependencyIndexes: file_params_proto_depIdxs,
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -27,13 +28,13 @@ func TestGetProfile(t *testing.T) {
 			name: "user does not exist",
 			id:   "2",
 			user: "",
-			code: http.StatusBadRequest,
+			code: http.StatusNotFound,
 			err:  service.ErrUserDoesNotExists,
 		},
 		{
 			name: "existed user",
 			id:   "1",
-			user: `{"name":"Ivan","phone_number":"+7455456","email":"ripper@algsdh","raiting":0}`,
+			user: `{"name":"Ivan","phone_number":"+74554567890","email":"ripper@algsdh.ru","raiting":0}`,
 			code: http.StatusOK,
 			err:  nil,
 		},
@@ -58,6 +59,8 @@ func TestGetProfile(t *testing.T) {
 	}
 }
 
+// TestUpdateProfile changes the email and the phone number of the user. They
+// are held until the codes sent to the new values are confirmed.
 func TestUpdateProfile(t *testing.T) {
 	h, err := InitHandler()
 	if err != nil {
@@ -65,24 +68,85 @@ func TestUpdateProfile(t *testing.T) {
 	}
 
 	test := []struct {
-		name string
-		id   string
-		body string
-		code int
-		err  error
+		name   string
+		method string
+		path   string
+		body   func() string
+		code   int
+		want   string
+		err    error
 	}{
 		{
-			name: "user does not exist",
-			id:   "2",
-			body: "",
-			code: http.StatusBadRequest,
+			name:   "user does not exist",
+			method: "PUT",
+			path:   "/users/profile/2",
+			body: func() string {
+				return `{"name": "Petr"}`
+			},
+			code: http.StatusNotFound,
 			err:  service.ErrUserDoesNotExists,
 		},
 		{
-			name: "existed user",
-			id:   "1",
-			body: `{"phone_number": "+77777778","email": "ripper@mail.ru"}`,
+			name:   "existed user",
+			method: "PUT",
+			path:   "/users/profile/1",
+			body: func() string {
+				return `{"phone_number": "+77777777778","email": "ripper@mail.ru"}`
+			},
+			code: http.StatusOK,
+			want: `{"pending":["email","phone_number"]}`,
+			err:  nil,
+		},
+		{
+			name:   "changes are pending",
+			method: "GET",
+			path:   "/users/profile/1",
+			body: func() string {
+				return ""
+			},
+			code: http.StatusOK,
+			want: `{"name":"Ivan","phone_number":"+74554567890","email":"ripper@algsdh.ru","raiting":0}`,
+			err:  nil,
+		},
+		{
+			name:   "wrong email code",
+			method: "POST",
+			path:   "/users/profile/1/email/confirm",
+			body: func() string {
+				return fmt.Sprintf(`{"code": "%s"}`, wrongCode("ripper@mail.ru"))
+			},
+			code: http.StatusBadRequest,
+			err:  service.ErrWrongOTP,
+		},
+		{
+			name:   "confirm email",
+			method: "POST",
+			path:   "/users/profile/1/email/confirm",
+			body: func() string {
+				return fmt.Sprintf(`{"code": "%s"}`, lastCode("ripper@mail.ru"))
+			},
+			code: http.StatusOK,
+			err:  nil,
+		},
+		{
+			name:   "confirm phone number",
+			method: "POST",
+			path:   "/users/profile/1/phone/confirm",
+			body: func() string {
+				return fmt.Sprintf(`{"code": "%s"}`, lastCode("+77777777778"))
+			},
+			code: http.StatusOK,
+			err:  nil,
+		},
+		{
+			name:   "changes are stored",
+			method: "GET",
+			path:   "/users/profile/1",
+			body: func() string {
+				return ""
+			},
 			code: http.StatusOK,
+			want: `{"name":"Ivan","phone_number":"+77777777778","email":"ripper@mail.ru","raiting":0}`,
 			err:  nil,
 		},
 	}
@@ -90,15 +154,23 @@ func TestUpdateProfile(t *testing.T) {
 	for _, tt := range test {
 		t.Run(tt.name, func(t *testing.T) {
 			r := SetUpRouter()
+			r.GET("/users/profile/:id", h.GetProfile)
 			r.PUT("/users/profile/:id", h.UpdateProfile)
+			r.POST("/users/profile/:id/email/confirm", h.ConfirmEmail)
+			r.POST("/users/profile/:id/phone/confirm", h.ConfirmPhone)
 
-			req, _ := http.NewRequest("PUT", "/users/profile/"+tt.id, bytes.NewBufferString(tt.body))
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body()))
 			w := httptest.NewRecorder()
 			r.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.code, w.Code)
-			assert.IsEqual(tt.err, w.Body.String())
-
+			if tt.err != nil {
+				assert.IsEqual(tt.err, w.Body.String())
+				return
+			}
+			if tt.want != "" {
+				assert.Equal(t, tt.want, w.Body.String())
+			}
 		})
 	}
 }
@@ -118,7 +190,7 @@ func TestDeleteUser(t *testing.T) {
 		{
 			name: "user does not exist",
 			id:   "2",
-			code: http.StatusBadRequest,
+			code: http.StatusNotFound,
 			err:  service.ErrUserDoesNotExists,
 		},
 		{